@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/drift"
+)
+
+// exit codes
+const (
+	exitInSync = 0
+	exitDrift  = 1
+	exitError  = 2
+)
+
+func main() {
+	rpcURL := flag.String("rpc", "http://localhost:8899", "solana rpc endpoint")
+	program := flag.String("program", "", "OCR2 program ID (base58)")
+	state := flag.String("state", "", "OCR2 state account (base58)")
+	config := flag.String("config", "", "desired config document (JSON)")
+	commitment := flag.String("commitment", string(rpc.CommitmentConfirmed), "rpc commitment")
+	timeout := flag.Duration("timeout", 30*time.Second, "request timeout")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	if *program == "" || *state == "" || *config == "" || (*format != "text" && *format != "json") {
+		flag.Usage()
+		os.Exit(exitError)
+	}
+
+	programID, err := solana.PublicKeyFromBase58(*program)
+	if err != nil {
+		log.Printf("invalid program ID: %s", err)
+		os.Exit(exitError)
+	}
+	stateID, err := solana.PublicKeyFromBase58(*state)
+	if err != nil {
+		log.Printf("invalid state account: %s", err)
+		os.Exit(exitError)
+	}
+	f, err := os.Open(*config)
+	if err != nil {
+		log.Printf("failed to open desired config: %s", err)
+		os.Exit(exitError)
+	}
+	desired, err := drift.ParseDesired(f)
+	f.Close()
+	if err != nil {
+		log.Print(err)
+		os.Exit(exitError)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	diff, err := drift.Check(ctx, rpc.New(*rpcURL), programID, stateID, desired, rpc.CommitmentType(*commitment))
+	if err != nil {
+		log.Print(err)
+		os.Exit(exitError)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if diff == nil {
+			diff = drift.Diff{}
+		}
+		if err := enc.Encode(diff); err != nil {
+			log.Printf("failed to encode output: %s", err)
+			os.Exit(exitError)
+		}
+	} else {
+		for _, d := range diff {
+			fmt.Println(d)
+		}
+	}
+	if len(diff) > 0 {
+		os.Exit(exitDrift)
+	}
+	os.Exit(exitInSync)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+// txReader adds versioned transaction support to the solana rpc client
+type txReader struct {
+	*rpc.Client
+}
+
+func (r txReader) GetTransaction(ctx context.Context, txSig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	return client.GetTransaction(ctx, r.Client, txSig, opts)
+}
+
+// output is the JSON summary of a decoded transmission
+type output struct {
+	Signature       string      `json:"signature"`
+	Slot            uint64      `json:"slot"`
+	BlockTime       *time.Time  `json:"blockTime,omitempty"`
+	Err             interface{} `json:"err,omitempty"`
+	State           string      `json:"state"`
+	Transmitter     string      `json:"transmitter"`
+	ConfigDigest    string      `json:"configDigest"`
+	Epoch           uint32      `json:"epoch"`
+	Round           uint8       `json:"round"`
+	ReportTimestamp uint32      `json:"reportTimestamp"`
+	Observers       []uint8     `json:"observers"`
+	Median          string      `json:"median"`
+	JuelsPerFeeCoin string      `json:"juelsPerFeeCoin"`
+	Signatures      []string    `json:"signatures"`
+	Signers         []int       `json:"signers,omitempty"`
+	Verified        bool        `json:"verified"`
+	VerifyError     string      `json:"verifyError,omitempty"`
+}
+
+func main() {
+	rpcURL := flag.String("rpc", "http://localhost:8899", "solana rpc endpoint")
+	program := flag.String("program", "", "OCR2 program ID (base58)")
+	commitment := flag.String("commitment", string(rpc.CommitmentConfirmed), "rpc commitment")
+	timeout := flag.Duration("timeout", 30*time.Second, "request timeout")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <tx signature>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *program == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	programID, err := solana.PublicKeyFromBase58(*program)
+	if err != nil {
+		log.Fatalf("invalid program ID: %s", err)
+	}
+	reader := txReader{rpc.New(*rpcURL)}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	failed := false
+	for _, arg := range flag.Args() {
+		out, err := decode(reader, programID, arg, rpc.CommitmentType(*commitment), *timeout)
+		if err != nil {
+			log.Printf("%s: %s", arg, err)
+			failed = true
+			continue
+		}
+		if err := enc.Encode(out); err != nil {
+			log.Fatalf("failed to encode output: %s", err)
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func decode(reader txReader, programID solana.PublicKey, sig string, commitment rpc.CommitmentType, timeout time.Duration) (output, error) {
+	txSig, err := solana.SignatureFromBase58(sig)
+	if err != nil {
+		return output{}, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	tx, err := relaySol.FetchTransmitTx(ctx, reader, programID, txSig, commitment)
+	if err != nil {
+		return output{}, err
+	}
+
+	out := output{
+		Signature:       tx.Signature.String(),
+		Slot:            tx.Slot,
+		Err:             tx.Err,
+		State:           tx.StateID.String(),
+		Transmitter:     tx.Transmitter.String(),
+		ConfigDigest:    tx.ReportContext.ConfigDigest.Hex(),
+		Epoch:           tx.ReportContext.Epoch,
+		Round:           tx.ReportContext.Round,
+		ReportTimestamp: tx.Report.Timestamp,
+		Observers:       tx.Report.ObserverIDs(),
+		Median:          tx.Report.Median.String(),
+		JuelsPerFeeCoin: tx.Report.JuelsPerFeeCoin.String(),
+	}
+	if tx.BlockTime != nil {
+		t := tx.BlockTime.Time()
+		out.BlockTime = &t
+	}
+	for _, s := range tx.Signatures {
+		out.Signatures = append(out.Signatures, hex.EncodeToString(s.Signature))
+	}
+
+	// verify against the oracle set of the config in effect at the transmission
+	state, _, err := relaySol.GetState(ctx, reader, programID, tx.StateID, commitment)
+	if err != nil {
+		return output{}, err
+	}
+	out.Signers, err = tx.VerifySignatures(ctx, reader, state, commitment, logger.NullLogger)
+	out.Verified = err == nil
+	if err != nil {
+		out.VerifyError = err.Error()
+	}
+	return out, nil
+}
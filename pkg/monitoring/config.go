@@ -6,16 +6,18 @@ import (
 	"os"
 	"time"
 
+	"github.com/gagliardetto/solana-go"
 	relayMonitoring "github.com/smartcontractkit/chainlink-relay/pkg/monitoring"
 )
 
 type SolanaConfig struct {
-	RPCEndpoint  string
-	NetworkName  string
-	NetworkID    string
-	ChainID      string
-	ReadTimeout  time.Duration
-	PollInterval time.Duration
+	RPCEndpoint    string
+	NetworkName    string
+	NetworkID      string
+	ChainID        string
+	ReadTimeout    time.Duration
+	PollInterval   time.Duration
+	StoreProgramID solana.PublicKey
 }
 
 var _ relayMonitoring.ChainConfig = SolanaConfig{}
@@ -75,6 +77,13 @@ func parseEnvVars(cfg *SolanaConfig) error {
 		}
 		cfg.PollInterval = pollInterval
 	}
+	if value, isPresent := os.LookupEnv("SOLANA_STORE_PROGRAM_ID"); isPresent {
+		storeProgramID, err := solana.PublicKeyFromBase58(value)
+		if err != nil {
+			return fmt.Errorf("failed to parse env var SOLANA_STORE_PROGRAM_ID as a base58 public key: %w", err)
+		}
+		cfg.StoreProgramID = storeProgramID
+	}
 	return nil
 }
 
@@ -90,6 +99,9 @@ func validateConfig(cfg SolanaConfig) error {
 			return fmt.Errorf("'%s' env var is required", envVarName)
 		}
 	}
+	if cfg.StoreProgramID.IsZero() {
+		return fmt.Errorf("'SOLANA_STORE_PROGRAM_ID' env var is required")
+	}
 	// Validate URLs.
 	for envVarName, currentValue := range map[string]string{
 		"SOLANA_RPC_ENDPOINT": cfg.RPCEndpoint,
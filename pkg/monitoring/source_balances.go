@@ -57,7 +57,7 @@ type balancesSource struct {
 }
 
 func (s *balancesSource) Fetch(ctx context.Context) (interface{}, error) {
-	state, _, err := pkgSolana.GetState(ctx, s.client, s.feedConfig.StateAccount, rpc.CommitmentConfirmed)
+	state, _, err := pkgSolana.GetState(ctx, s.client, s.feedConfig.ContractAddress, s.feedConfig.StateAccount, rpc.CommitmentConfirmed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get contract state: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	relayMonitoring "github.com/smartcontractkit/chainlink-relay/pkg/monitoring"
 	pkgSolana "github.com/smartcontractkit/chainlink-solana/pkg/solana"
@@ -30,16 +31,21 @@ type envelopeSourceFactory struct {
 }
 
 func (s *envelopeSourceFactory) NewSource(
-	_ relayMonitoring.ChainConfig,
+	chainConfig relayMonitoring.ChainConfig,
 	feedConfig relayMonitoring.FeedConfig,
 ) (relayMonitoring.Source, error) {
+	solanaChainConfig, ok := chainConfig.(SolanaConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected chainConfig to be of type SolanaConfig not %T", chainConfig)
+	}
 	solanaFeedConfig, ok := feedConfig.(SolanaFeedConfig)
 	if !ok {
 		return nil, fmt.Errorf("expected feedConfig to be of type SolanaFeedConfig not %T", feedConfig)
 	}
 	return &envelopeSource{
-		s.client,
-		solanaFeedConfig,
+		client:      s.client,
+		chainConfig: solanaChainConfig,
+		feedConfig:  solanaFeedConfig,
 	}, nil
 }
 
@@ -48,12 +54,18 @@ func (s *envelopeSourceFactory) GetType() string {
 }
 
 type envelopeSource struct {
-	client     *rpc.Client
-	feedConfig SolanaFeedConfig
+	client      *rpc.Client
+	chainConfig SolanaConfig
+	feedConfig  SolanaFeedConfig
+
+	// reader of the transmissions account, kept across fetches to locate the latest transmission with a single request
+	feed        *pkgSolana.Feed
+	feedAccount solana.PublicKey
+	feedLock    sync.Mutex
 }
 
 func (s *envelopeSource) Fetch(ctx context.Context) (interface{}, error) {
-	state, blockNum, err := pkgSolana.GetState(ctx, s.client, s.feedConfig.StateAccount, rpc.CommitmentConfirmed)
+	state, blockNum, err := pkgSolana.GetState(ctx, s.client, s.feedConfig.ContractAddress, s.feedConfig.StateAccount, rpc.CommitmentConfirmed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch state from on-chain: %w", err)
 	}
@@ -71,8 +83,8 @@ func (s *envelopeSource) Fetch(ctx context.Context) (interface{}, error) {
 	go func() {
 		defer wg.Done()
 		var transmissionErr error
-		answer, _, transmissionErr = pkgSolana.GetLatestTransmission(ctx, s.client, state.Transmissions, rpc.CommitmentConfirmed)
-		if err != nil {
+		answer, _, transmissionErr = s.transmissionsFeed(state.Transmissions).LatestTransmission(ctx)
+		if transmissionErr != nil {
 			envelopeErr = multierr.Combine(envelopeErr, fmt.Errorf("failed to fetch latest on-chain transmission: %w", transmissionErr))
 		}
 	}()
@@ -126,6 +138,16 @@ func (s *envelopeSource) Fetch(ctx context.Context) (interface{}, error) {
 
 // Helpers
 
+func (s *envelopeSource) transmissionsFeed(transmissions solana.PublicKey) *pkgSolana.Feed {
+	s.feedLock.Lock()
+	defer s.feedLock.Unlock()
+	if s.feed == nil || s.feedAccount != transmissions {
+		s.feed = pkgSolana.NewFeed(s.client, s.chainConfig.StoreProgramID, transmissions, rpc.CommitmentConfirmed)
+		s.feedAccount = transmissions
+	}
+	return s.feed
+}
+
 func getLinkAvailableForPayment(state pkgSolana.State, linkBalance *big.Int) (*big.Int, error) {
 	oracles, err := state.Oracles.Data()
 	if err != nil {
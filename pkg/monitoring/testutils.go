@@ -25,6 +25,8 @@ func generateChainConfig() SolanaConfig {
 		ChainID:      "solana-mainnet-beta",
 		ReadTimeout:  100 * time.Millisecond,
 		PollInterval: time.Duration(1+rand.Intn(5)) * time.Second,
+
+		StoreProgramID: generatePublicKey(),
 	}
 }
 
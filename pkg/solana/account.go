@@ -0,0 +1,61 @@
+package solana
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// AccountOwnerError is returned when an account is not owned by the expected program
+type AccountOwnerError struct {
+	Account  solana.PublicKey
+	Owner    solana.PublicKey
+	Expected solana.PublicKey
+}
+
+func (e *AccountOwnerError) Error() string {
+	return fmt.Sprintf("account %s is owned by %s, expected program %s", e.Account, e.Owner, e.Expected)
+}
+
+// AccountDiscriminatorError is returned when the Anchor discriminator of an account does not match the expected account type
+type AccountDiscriminatorError struct {
+	Account       solana.PublicKey // unset when decoding raw data
+	Type          string
+	Discriminator []byte
+	Expected      [8]byte
+}
+
+func (e *AccountDiscriminatorError) Error() string {
+	if e.Account.IsZero() {
+		return fmt.Sprintf("account data is not a %s account: discriminator %x, expected %x", e.Type, e.Discriminator, e.Expected)
+	}
+	return fmt.Sprintf("account %s is not a %s account: discriminator %x, expected %x", e.Account, e.Type, e.Discriminator, e.Expected)
+}
+
+func checkOwner(account solana.PublicKey, info *rpc.Account, program solana.PublicKey) error {
+	if !info.Owner.Equals(program) {
+		return &AccountOwnerError{Account: account, Owner: info.Owner, Expected: program}
+	}
+	return nil
+}
+
+func checkDiscriminator(data []byte, typ string, expected [8]byte) error {
+	if uint64(len(data)) < AccountDiscriminatorLen || !bytes.Equal(data[:AccountDiscriminatorLen], expected[:]) {
+		n := len(data)
+		if uint64(n) > AccountDiscriminatorLen {
+			n = int(AccountDiscriminatorLen)
+		}
+		return &AccountDiscriminatorError{Type: typ, Discriminator: append([]byte{}, data[:n]...), Expected: expected}
+	}
+	return nil
+}
+
+// withAccount sets the account of discriminator errors returned while decoding its data
+func withAccount(err error, account solana.PublicKey) error {
+	if e, ok := err.(*AccountDiscriminatorError); ok {
+		e.Account = account
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	storeprogram "github.com/smartcontractkit/chainlink-solana/contracts/generated/store"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+)
+
+func TestGetState_AccountValidation(t *testing.T) {
+	programID, stateID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	state := State{AccountDiscriminator: ocr_2.StateDiscriminator, Version: configVersion}
+	var buf bytes.Buffer
+	require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	require.NoError(t, err)
+
+	reader := new(mocks.ReaderWriter)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, stateID, mock.Anything).Return(&rpc.GetAccountInfoResult{
+		Value: &rpc.Account{Owner: programID, Data: data},
+	}, nil)
+	_, _, err = GetState(context.Background(), reader, programID, stateID, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+
+	// account owned by another program
+	other := solana.NewWallet().PublicKey()
+	_, _, err = GetState(context.Background(), reader, other, stateID, rpc.CommitmentConfirmed)
+	var ownerErr *AccountOwnerError
+	require.True(t, errors.As(err, &ownerErr))
+	assert.Equal(t, AccountOwnerError{Account: stateID, Owner: programID, Expected: other}, *ownerErr)
+
+	// account of another type, e.g. a proposal account passed as state
+	_, err = DecodeState(append(make([]byte, AccountDiscriminatorLen), buf.Bytes()[AccountDiscriminatorLen:]...))
+	var discriminatorErr *AccountDiscriminatorError
+	require.True(t, errors.As(err, &discriminatorErr))
+	assert.Equal(t, "State", discriminatorErr.Type)
+	assert.True(t, discriminatorErr.Account.IsZero())
+}
+
+func TestGetLatestTransmission_AccountValidation(t *testing.T) {
+	store := newTestStore(t, 4, 3, 2)
+	store.insert(3)
+	account := solana.NewWallet().PublicKey()
+
+	other := solana.NewWallet().PublicKey()
+	_, _, err := GetLatestTransmission(context.Background(), store, other, account, rpc.CommitmentConfirmed)
+	var ownerErr *AccountOwnerError
+	require.True(t, errors.As(err, &ownerErr))
+	assert.Equal(t, store.program, ownerErr.Owner)
+	_, err = GetRound(context.Background(), NewFeed(store, other, account, rpc.CommitmentConfirmed), 3)
+	assert.True(t, errors.As(err, &ownerErr))
+
+	// state account owned by the store program is not a transmissions account
+	reader := new(mocks.ReaderWriter)
+	state := State{AccountDiscriminator: ocr_2.StateDiscriminator, Version: configVersion}
+	var buf bytes.Buffer
+	require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()[:AccountDiscriminatorLen+TransmissionsHeaderLen]))
+	require.NoError(t, err)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, account, mock.Anything).Return(&rpc.GetAccountInfoResult{
+		Value: &rpc.Account{Owner: store.program, Data: data},
+	}, nil)
+	_, _, err = GetLatestTransmission(context.Background(), reader, store.program, account, rpc.CommitmentConfirmed)
+	var discriminatorErr *AccountDiscriminatorError
+	require.True(t, errors.As(err, &discriminatorErr))
+	assert.Equal(t, AccountDiscriminatorError{
+		Account:       account,
+		Type:          "Transmissions",
+		Discriminator: ocr_2.StateDiscriminator[:],
+		Expected:      storeprogram.TransmissionsDiscriminator,
+	}, *discriminatorErr)
+	_, _, err = GetFeedHeader(context.Background(), reader, store.program, account, rpc.CommitmentConfirmed)
+	assert.True(t, errors.As(err, &discriminatorErr))
+}
@@ -0,0 +1,251 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// Status of a transmission
+type Status string
+
+const (
+	StatusQueued    Status = "queued"    // transaction signed and handed to the tx manager
+	StatusFailed    Status = "failed"    // Transmit errored or the transaction failed on-chain
+	StatusConfirmed Status = "confirmed" // transaction reached the configured commitment
+)
+
+// Record is a single append-only audit entry.
+// Every Transmit call appends one record, later status changes of the same transaction
+// append further records with StatusUpdate set and only StateID, TxSignature, Status, Error and Time populated.
+type Record struct {
+	Time         time.Time
+	StateID      solana.PublicKey
+	StatusUpdate bool
+
+	// report context
+	ConfigDigest types.ConfigDigest
+	Epoch        uint32
+	Round        uint8
+
+	// decoded report
+	Median          *big.Int
+	ReportTimestamp uint32
+	Observers       []int
+
+	Signatures  int
+	TxSignature solana.Signature // zero if Transmit failed before signing
+	Status      Status
+	Error       string
+}
+
+// Filter selects records on Query, zero values match everything
+type Filter struct {
+	StateID      solana.PublicKey
+	ConfigDigest types.ConfigDigest
+	TxSignature  solana.Signature
+	Since        time.Time
+	Until        time.Time
+	Limit        int // most recent records, applied after merging status updates
+}
+
+func (f Filter) match(r Record) bool {
+	if !f.StateID.IsZero() && !f.StateID.Equals(r.StateID) {
+		return false
+	}
+	if !f.TxSignature.IsZero() && f.TxSignature != r.TxSignature {
+		return false
+	}
+	if !f.Since.IsZero() && r.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.Time.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Sink stores audit records, implementations must only ever append
+type Sink interface {
+	Append(ctx context.Context, r Record) error
+	// Query returns raw records matching the StateID, TxSignature and time bounds of the filter, oldest first
+	Query(ctx context.Context, f Filter) ([]Record, error)
+}
+
+// StatusChecker is implemented by clients able to query signature statuses (client.Writer)
+type StatusChecker interface {
+	SignatureStatuses(ctx context.Context, sigs []solana.Signature) ([]*rpc.SignatureStatusesResult, error)
+}
+
+// Log is the audit log API on top of a Sink
+type Log struct {
+	sink Sink
+
+	// queued transmissions awaiting a final status
+	pending map[solana.Signature]pendingTx
+	lock    sync.Mutex
+}
+
+type pendingTx struct {
+	stateID solana.PublicKey
+	queued  time.Time
+}
+
+// NewLog returns a Log on top of sink, transmissions queued without a final status in the sink are resolved again
+func NewLog(ctx context.Context, sink Sink) (*Log, error) {
+	l := &Log{
+		sink:    sink,
+		pending: map[solana.Signature]pendingTx{},
+	}
+	raw, err := sink.Query(ctx, Filter{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error in NewLog")
+	}
+	for _, r := range raw {
+		switch {
+		case r.TxSignature.IsZero():
+		case !r.StatusUpdate && r.Status == StatusQueued:
+			l.pending[r.TxSignature] = pendingTx{stateID: r.StateID, queued: r.Time}
+		case r.Status != StatusQueued:
+			delete(l.pending, r.TxSignature)
+		}
+	}
+	return l, nil
+}
+
+// Append records a Transmit call
+func (l *Log) Append(ctx context.Context, r Record) error {
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	if err := l.sink.Append(ctx, r); err != nil {
+		return errors.Wrap(err, "error in Log.Append")
+	}
+	if r.Status == StatusQueued && !r.TxSignature.IsZero() {
+		l.lock.Lock()
+		l.pending[r.TxSignature] = pendingTx{stateID: r.StateID, queued: r.Time}
+		l.lock.Unlock()
+	}
+	return nil
+}
+
+// SetStatus appends a status change for a previously recorded transaction
+func (l *Log) SetStatus(ctx context.Context, stateID solana.PublicKey, txSig solana.Signature, status Status, reason string) error {
+	err := l.sink.Append(ctx, Record{
+		Time:         time.Now(),
+		StateID:      stateID,
+		StatusUpdate: true,
+		TxSignature:  txSig,
+		Status:       status,
+		Error:        reason,
+	})
+	if err != nil {
+		return errors.Wrap(err, "error in Log.SetStatus")
+	}
+	if status != StatusQueued {
+		l.lock.Lock()
+		delete(l.pending, txSig)
+		l.lock.Unlock()
+	}
+	return nil
+}
+
+// ResolvePending checks the status of queued transactions and records their final status.
+// Transactions are marked confirmed once they reach commitment, those not seen on-chain within timeout are marked failed.
+func (l *Log) ResolvePending(ctx context.Context, checker StatusChecker, commitment rpc.CommitmentType, timeout time.Duration) error {
+	l.lock.Lock()
+	sigs := make([]solana.Signature, 0, len(l.pending))
+	pending := make([]pendingTx, 0, len(l.pending))
+	for sig, p := range l.pending {
+		sigs = append(sigs, sig)
+		pending = append(pending, p)
+	}
+	l.lock.Unlock()
+	if len(sigs) == 0 {
+		return nil
+	}
+
+	statuses, err := checker.SignatureStatuses(ctx, sigs)
+	if err != nil {
+		return errors.Wrap(err, "error in ResolvePending.SignatureStatuses")
+	}
+	if len(statuses) != len(sigs) {
+		return errors.Errorf("error in ResolvePending: expected %d statuses, received %d", len(sigs), len(statuses))
+	}
+
+	for i, s := range statuses {
+		switch {
+		case s == nil:
+			if time.Since(pending[i].queued) < timeout {
+				continue
+			}
+			err = l.SetStatus(ctx, pending[i].stateID, sigs[i], StatusFailed, "transaction not found before timeout")
+		case s.Err != nil:
+			err = l.SetStatus(ctx, pending[i].stateID, sigs[i], StatusFailed, fmt.Sprint(s.Err))
+		case reached(s.ConfirmationStatus, commitment):
+			err = l.SetStatus(ctx, pending[i].stateID, sigs[i], StatusConfirmed, "")
+		default:
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reached reports whether a transaction with the confirmation status satisfies the commitment level
+func reached(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	switch commitment {
+	case rpc.CommitmentProcessed:
+		return status == rpc.ConfirmationStatusProcessed || status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	default:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	}
+}
+
+// Query returns the transmissions matching the filter, oldest first, with status updates merged into their transmission.
+// The time bounds apply to transmissions, their status updates are merged even when recorded after Until.
+func (l *Log) Query(ctx context.Context, f Filter) ([]Record, error) {
+	// status updates are recorded after their transmission, so only Since bounds the records read from the sink
+	raw, err := l.sink.Query(ctx, Filter{StateID: f.StateID, TxSignature: f.TxSignature, Since: f.Since})
+	if err != nil {
+		return nil, errors.Wrap(err, "error in Log.Query")
+	}
+
+	out := []Record{}
+	index := map[solana.Signature]int{}
+	for _, r := range raw {
+		if r.StatusUpdate {
+			if i, ok := index[r.TxSignature]; ok {
+				out[i].Status = r.Status
+				out[i].Error = r.Error
+			}
+			continue
+		}
+		if f.ConfigDigest != (types.ConfigDigest{}) && f.ConfigDigest != r.ConfigDigest {
+			continue
+		}
+		if !f.Until.IsZero() && r.Time.After(f.Until) {
+			continue
+		}
+		if !r.TxSignature.IsZero() {
+			index[r.TxSignature] = len(out)
+		}
+		out = append(out, r)
+	}
+
+	if f.Limit > 0 && len(out) > f.Limit {
+		out = out[len(out)-f.Limit:]
+	}
+	return out, nil
+}
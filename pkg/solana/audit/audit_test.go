@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testChecker struct {
+	statuses map[solana.Signature]*rpc.SignatureStatusesResult
+}
+
+func (c testChecker) SignatureStatuses(_ context.Context, sigs []solana.Signature) ([]*rpc.SignatureStatusesResult, error) {
+	out := make([]*rpc.SignatureStatusesResult, len(sigs))
+	for i, s := range sigs {
+		out[i] = c.statuses[s]
+	}
+	return out, nil
+}
+
+func TestLog_FileSink(t *testing.T) {
+	ctx := context.Background()
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	log, err := NewLog(ctx, sink)
+	require.NoError(t, err)
+
+	stateA := solana.NewWallet().PublicKey()
+	stateB := solana.NewWallet().PublicKey()
+	digest := types.ConfigDigest{0, 3, 1, 2}
+	confirmed, dropped, failed := solana.Signature{1}, solana.Signature{2}, solana.Signature{3}
+
+	records := []Record{
+		{StateID: stateA, ConfigDigest: digest, Epoch: 1, Round: 2, Median: big.NewInt(-14), ReportTimestamp: 100, Observers: []int{0, 1, 2}, Signatures: 2, TxSignature: confirmed, Status: StatusQueued},
+		{StateID: stateA, ConfigDigest: digest, Epoch: 1, Round: 3, Median: big.NewInt(15), ReportTimestamp: 101, Observers: []int{2, 1, 0}, Signatures: 2, TxSignature: dropped, Status: StatusQueued},
+		{StateID: stateA, ConfigDigest: types.ConfigDigest{9}, Epoch: 2, Round: 1, Signatures: 2, Status: StatusFailed, Error: "error on Transmit.ReadState"},
+		{StateID: stateB, ConfigDigest: digest, Epoch: 1, Round: 2, Median: big.NewInt(16), Signatures: 2, TxSignature: failed, Status: StatusQueued},
+	}
+	for _, r := range records {
+		require.NoError(t, log.Append(ctx, r))
+	}
+
+	// resolve final status of queued transactions
+	checker := testChecker{statuses: map[solana.Signature]*rpc.SignatureStatusesResult{
+		confirmed: {ConfirmationStatus: rpc.ConfirmationStatusConfirmed},
+		failed:    {ConfirmationStatus: rpc.ConfirmationStatusConfirmed, Err: "custom program error"},
+	}}
+	require.NoError(t, log.ResolvePending(ctx, checker, rpc.CommitmentConfirmed, time.Hour))
+	assert.Len(t, log.pending, 1) // dropped is not yet timed out
+	require.NoError(t, log.ResolvePending(ctx, checker, rpc.CommitmentConfirmed, 0))
+	assert.Len(t, log.pending, 0)
+
+	out, err := log.Query(ctx, Filter{StateID: stateA})
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+	assert.Equal(t, StatusConfirmed, out[0].Status)
+	assert.Equal(t, "-14", out[0].Median.String())
+	assert.Equal(t, digest, out[0].ConfigDigest)
+	assert.Equal(t, []int{0, 1, 2}, out[0].Observers)
+	assert.Equal(t, uint32(100), out[0].ReportTimestamp)
+	assert.Equal(t, StatusFailed, out[1].Status)
+	assert.Equal(t, "transaction not found before timeout", out[1].Error)
+	assert.Equal(t, StatusFailed, out[2].Status)
+	assert.True(t, out[2].TxSignature.IsZero())
+	assert.Nil(t, out[2].Median)
+
+	out, err = log.Query(ctx, Filter{StateID: stateA, ConfigDigest: digest, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, dropped, out[0].TxSignature)
+
+	out, err = log.Query(ctx, Filter{TxSignature: failed})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, stateB, out[0].StateID)
+	assert.Equal(t, StatusFailed, out[0].Status)
+	assert.Equal(t, "custom program error", out[0].Error)
+
+	out, err = log.Query(ctx, Filter{Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
+
+func TestLog_ResolvePending_Commitment(t *testing.T) {
+	ctx := context.Background()
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	log, err := NewLog(ctx, sink)
+	require.NoError(t, err)
+
+	state, sig := solana.NewWallet().PublicKey(), solana.Signature{1}
+	require.NoError(t, log.Append(ctx, Record{StateID: state, TxSignature: sig, Status: StatusQueued}))
+
+	// confirmed is not yet final with finalized commitment
+	checker := testChecker{statuses: map[solana.Signature]*rpc.SignatureStatusesResult{
+		sig: {ConfirmationStatus: rpc.ConfirmationStatusConfirmed},
+	}}
+	require.NoError(t, log.ResolvePending(ctx, checker, rpc.CommitmentFinalized, 0))
+	assert.Len(t, log.pending, 1)
+
+	checker.statuses[sig] = &rpc.SignatureStatusesResult{ConfirmationStatus: rpc.ConfirmationStatusFinalized}
+	require.NoError(t, log.ResolvePending(ctx, checker, rpc.CommitmentFinalized, 0))
+	assert.Len(t, log.pending, 0)
+
+	out, err := log.Query(ctx, Filter{TxSignature: sig})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, StatusConfirmed, out[0].Status)
+}
+
+func TestLog_QueryUntil(t *testing.T) {
+	ctx := context.Background()
+	sink, err := NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	log, err := NewLog(ctx, sink)
+	require.NoError(t, err)
+
+	state := solana.NewWallet().PublicKey()
+	queued := time.Now().Add(-time.Hour)
+	require.NoError(t, log.Append(ctx, Record{Time: queued, StateID: state, TxSignature: solana.Signature{1}, Status: StatusQueued}))
+	require.NoError(t, log.Append(ctx, Record{StateID: state, TxSignature: solana.Signature{2}, Status: StatusQueued}))
+	require.NoError(t, log.SetStatus(ctx, state, solana.Signature{1}, StatusConfirmed, ""))
+
+	// status updates recorded after Until are merged into transmissions within the bounds
+	out, err := log.Query(ctx, Filter{StateID: state, Until: queued.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, solana.Signature{1}, out[0].TxSignature)
+	assert.Equal(t, StatusConfirmed, out[0].Status)
+}
+
+func TestLog_PendingRebuilt(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	log, err := NewLog(ctx, sink)
+	require.NoError(t, err)
+
+	state := solana.NewWallet().PublicKey()
+	pending, resolved := solana.Signature{1}, solana.Signature{2}
+	require.NoError(t, log.Append(ctx, Record{StateID: state, TxSignature: pending, Status: StatusQueued}))
+	require.NoError(t, log.Append(ctx, Record{StateID: state, TxSignature: resolved, Status: StatusQueued}))
+	require.NoError(t, log.Append(ctx, Record{StateID: state, Status: StatusFailed, Error: "error on Transmit.ReadState"}))
+	require.NoError(t, log.SetStatus(ctx, state, resolved, StatusConfirmed, ""))
+
+	// transmissions queued before a restart are resolved by the new log
+	sink, err = NewFileSink(path)
+	require.NoError(t, err)
+	log, err = NewLog(ctx, sink)
+	require.NoError(t, err)
+	require.Len(t, log.pending, 1)
+	assert.Equal(t, state, log.pending[pending].stateID)
+
+	checker := testChecker{statuses: map[solana.Signature]*rpc.SignatureStatusesResult{
+		pending: {ConfirmationStatus: rpc.ConfirmationStatusConfirmed},
+	}}
+	require.NoError(t, log.ResolvePending(ctx, checker, rpc.CommitmentConfirmed, time.Hour))
+	assert.Empty(t, log.pending)
+	out, err := log.Query(ctx, Filter{TxSignature: pending})
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, StatusConfirmed, out[0].Status)
+}
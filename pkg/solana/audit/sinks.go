@@ -0,0 +1,264 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// row is the flat, text encoded form of a Record shared by all sinks
+type row struct {
+	Time            time.Time `json:"time"`
+	StateID         string    `json:"stateID"`
+	StatusUpdate    bool      `json:"statusUpdate,omitempty"`
+	ConfigDigest    string    `json:"configDigest,omitempty"`
+	Epoch           uint32    `json:"epoch,omitempty"`
+	Round           uint8     `json:"round,omitempty"`
+	Median          string    `json:"median,omitempty"`
+	ReportTimestamp uint32    `json:"reportTimestamp,omitempty"`
+	Observers       []int     `json:"observers,omitempty"`
+	Signatures      int       `json:"signatures,omitempty"`
+	TxSignature     string    `json:"txSignature,omitempty"`
+	Status          Status    `json:"status"`
+	Error           string    `json:"error,omitempty"`
+}
+
+func toRow(r Record) row {
+	out := row{
+		Time:            r.Time.UTC(),
+		StateID:         r.StateID.String(),
+		StatusUpdate:    r.StatusUpdate,
+		Epoch:           r.Epoch,
+		Round:           r.Round,
+		ReportTimestamp: r.ReportTimestamp,
+		Observers:       r.Observers,
+		Signatures:      r.Signatures,
+		Status:          r.Status,
+		Error:           r.Error,
+	}
+	if r.ConfigDigest != (types.ConfigDigest{}) {
+		out.ConfigDigest = r.ConfigDigest.Hex()
+	}
+	if r.Median != nil {
+		out.Median = r.Median.String()
+	}
+	if !r.TxSignature.IsZero() {
+		out.TxSignature = r.TxSignature.String()
+	}
+	return out
+}
+
+func (r row) record() (Record, error) {
+	out := Record{
+		Time:            r.Time,
+		StatusUpdate:    r.StatusUpdate,
+		Epoch:           r.Epoch,
+		Round:           r.Round,
+		ReportTimestamp: r.ReportTimestamp,
+		Observers:       r.Observers,
+		Signatures:      r.Signatures,
+		Status:          r.Status,
+		Error:           r.Error,
+	}
+	var err error
+	if out.StateID, err = solana.PublicKeyFromBase58(r.StateID); err != nil {
+		return Record{}, errors.Wrapf(err, "invalid state account %q", r.StateID)
+	}
+	if r.ConfigDigest != "" {
+		b, err := hex.DecodeString(r.ConfigDigest)
+		if err != nil {
+			return Record{}, errors.Wrapf(err, "invalid config digest %q", r.ConfigDigest)
+		}
+		if out.ConfigDigest, err = types.BytesToConfigDigest(b); err != nil {
+			return Record{}, err
+		}
+	}
+	if r.Median != "" {
+		median, ok := new(big.Int).SetString(r.Median, 10)
+		if !ok {
+			return Record{}, fmt.Errorf("invalid median %q", r.Median)
+		}
+		out.Median = median
+	}
+	if r.TxSignature != "" {
+		if out.TxSignature, err = solana.SignatureFromBase58(r.TxSignature); err != nil {
+			return Record{}, errors.Wrapf(err, "invalid tx signature %q", r.TxSignature)
+		}
+	}
+	return out, nil
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// FileSink appends records as JSON lines to a file
+type FileSink struct {
+	path string
+	lock sync.Mutex
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	// create the file early to surface permission errors on startup
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in NewFileSink.OpenFile")
+	}
+	return &FileSink{path: path}, f.Close()
+}
+
+func (s *FileSink) Append(_ context.Context, r Record) error {
+	line, err := json.Marshal(toRow(r))
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "error in FileSink.Append.OpenFile")
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "error in FileSink.Append.Write")
+	}
+	return f.Close()
+}
+
+func (s *FileSink) Query(ctx context.Context, flt Filter) ([]Record, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f, err := os.Open(s.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in FileSink.Query.Open")
+	}
+	defer f.Close()
+
+	out := []Record{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var rw row
+		if err := json.Unmarshal(scanner.Bytes(), &rw); err != nil {
+			return nil, errors.Wrap(err, "error in FileSink.Query: malformed record")
+		}
+		r, err := rw.record()
+		if err != nil {
+			return nil, errors.Wrap(err, "error in FileSink.Query: malformed record")
+		}
+		if flt.match(r) {
+			out = append(out, r)
+		}
+	}
+	return out, errors.Wrap(scanner.Err(), "error in FileSink.Query.Scan")
+}
+
+// SQLSchema creates the table used by SQLSink (postgres)
+const SQLSchema = `CREATE TABLE IF NOT EXISTS solana_transmission_audit (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMPTZ NOT NULL,
+	state_id TEXT NOT NULL,
+	status_update BOOLEAN NOT NULL,
+	config_digest TEXT NOT NULL,
+	epoch BIGINT NOT NULL,
+	round SMALLINT NOT NULL,
+	median TEXT NOT NULL,
+	report_timestamp BIGINT NOT NULL,
+	observers TEXT NOT NULL,
+	signatures INTEGER NOT NULL,
+	tx_signature TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_solana_transmission_audit_state_id ON solana_transmission_audit (state_id, created_at);`
+
+var _ Sink = (*SQLSink)(nil)
+
+// SQLSink appends records to the solana_transmission_audit table, see SQLSchema
+type SQLSink struct {
+	db *sql.DB
+}
+
+func NewSQLSink(db *sql.DB) *SQLSink {
+	return &SQLSink{db: db}
+}
+
+func (s *SQLSink) Append(ctx context.Context, r Record) error {
+	rw := toRow(r)
+	observers, err := json.Marshal(rw.Observers)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO solana_transmission_audit
+		(created_at, state_id, status_update, config_digest, epoch, round, median, report_timestamp, observers, signatures, tx_signature, status, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		rw.Time, rw.StateID, rw.StatusUpdate, rw.ConfigDigest, rw.Epoch, rw.Round, rw.Median, rw.ReportTimestamp, string(observers), rw.Signatures, rw.TxSignature, string(rw.Status), rw.Error,
+	)
+	return errors.Wrap(err, "error in SQLSink.Append")
+}
+
+func (s *SQLSink) Query(ctx context.Context, f Filter) ([]Record, error) {
+	var where []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if !f.StateID.IsZero() {
+		add("state_id = $%d", f.StateID.String())
+	}
+	if !f.TxSignature.IsZero() {
+		add("tx_signature = $%d", f.TxSignature.String())
+	}
+	if !f.Since.IsZero() {
+		add("created_at >= $%d", f.Since)
+	}
+	if !f.Until.IsZero() {
+		add("created_at <= $%d", f.Until)
+	}
+	query := `SELECT created_at, state_id, status_update, config_digest, epoch, round, median, report_timestamp, observers, signatures, tx_signature, status, error
+		FROM solana_transmission_audit`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY id ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in SQLSink.Query")
+	}
+	defer rows.Close()
+
+	out := []Record{}
+	for rows.Next() {
+		var rw row
+		var observers, status string
+		if err := rows.Scan(&rw.Time, &rw.StateID, &rw.StatusUpdate, &rw.ConfigDigest, &rw.Epoch, &rw.Round, &rw.Median,
+			&rw.ReportTimestamp, &observers, &rw.Signatures, &rw.TxSignature, &status, &rw.Error); err != nil {
+			return nil, errors.Wrap(err, "error in SQLSink.Query.Scan")
+		}
+		if err := json.Unmarshal([]byte(observers), &rw.Observers); err != nil {
+			return nil, errors.Wrap(err, "error in SQLSink.Query: malformed observers")
+		}
+		rw.Status = Status(status)
+		r, err := rw.record()
+		if err != nil {
+			return nil, errors.Wrap(err, "error in SQLSink.Query: malformed record")
+		}
+		out = append(out, r)
+	}
+	return out, errors.Wrap(rows.Err(), "error in SQLSink.Query.Rows")
+}
@@ -32,6 +32,8 @@ type Reader interface {
 	LatestBlockhash() (*rpc.GetLatestBlockhashResult, error)
 	ChainID() (string, error)
 	GetFeeForMessage(msg string) (uint64, error)
+	GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error)
+	GetTransaction(ctx context.Context, txSig solana.Signature, opts *GetTransactionOpts) (*rpc.GetTransactionResult, error)
 }
 
 // AccountReader is an interface that allows users to pass either the solana rpc client or the relay client
@@ -101,6 +103,32 @@ func (c *Client) GetAccountInfoWithOpts(ctx context.Context, addr solana.PublicK
 	return c.rpc.GetAccountInfoWithOpts(ctx, addr, opts)
 }
 
+// https://docs.solana.com/developing/clients/jsonrpc-api#getsignaturesforaddress
+// The passed in commitment is used, a processed commitment is replaced by confirmed as history queries do not support it.
+func (c *Client) GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextDuration)
+	defer cancel()
+	opts.Commitment = historyCommitment(opts.Commitment)
+	return c.rpc.GetSignaturesForAddressWithOpts(ctx, account, opts)
+}
+
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettransaction
+// The passed in commitment is used, a processed commitment is replaced by confirmed as history queries do not support it.
+func (c *Client) GetTransaction(ctx context.Context, txSig solana.Signature, opts *GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.contextDuration)
+	defer cancel()
+	opts.Commitment = historyCommitment(opts.Commitment)
+	return GetTransaction(ctx, c.rpc, txSig, opts)
+}
+
+// historyCommitment returns the commitment for transaction history queries, which do not support processed
+func historyCommitment(commitment rpc.CommitmentType) rpc.CommitmentType {
+	if commitment == rpc.CommitmentProcessed {
+		return rpc.CommitmentConfirmed
+	}
+	return commitment
+}
+
 func (c *Client) LatestBlockhash() (*rpc.GetLatestBlockhashResult, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), c.contextDuration)
 	defer cancel()
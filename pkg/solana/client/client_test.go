@@ -2,10 +2,12 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -116,6 +118,55 @@ func TestClient_Reader_ChainID(t *testing.T) {
 	}
 }
 
+func TestClient_Reader_GetTransaction(t *testing.T) {
+	var params []json.RawMessage
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		params = req.Params
+		out := `{"jsonrpc":"2.0","result":{"slot":10,"transaction":["AQID","base64"],"meta":{"err":null,"logMessages":[]}},"id":1}`
+		if len(params) < 2 || !strings.Contains(string(params[1]), "maxSupportedTransactionVersion") {
+			out = `{"jsonrpc":"2.0","result":null,"id":1}`
+		}
+		_, err := w.Write([]byte(out))
+		require.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	lggr := logger.TestLogger(t)
+	c, err := NewClient(mockServer.URL, config.NewConfig(db.ChainCfg{}, lggr), 5*time.Second, lggr)
+	require.NoError(t, err)
+
+	// versioned transactions are requested, processed is not supported for history and is replaced
+	version := uint64(0)
+	res, err := c.GetTransaction(context.Background(), solana.Signature{1}, &GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentProcessed,
+		MaxSupportedTransactionVersion: &version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), res.Slot)
+	assert.Equal(t, []byte{1, 2, 3}, res.Transaction.GetBinary())
+	require.Len(t, params, 2)
+	assert.JSONEq(t, `{"encoding":"base64","commitment":"confirmed","maxSupportedTransactionVersion":0}`, string(params[1]))
+
+	// the caller commitment is kept otherwise
+	_, err = c.GetTransaction(context.Background(), solana.Signature{1}, &GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     rpc.CommitmentFinalized,
+		MaxSupportedTransactionVersion: &version,
+	})
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	assert.JSONEq(t, `{"encoding":"base64","commitment":"finalized","maxSupportedTransactionVersion":0}`, string(params[1]))
+
+	// missing transaction
+	_, err = c.GetTransaction(context.Background(), solana.Signature{1}, &GetTransactionOpts{})
+	assert.ErrorIs(t, err, rpc.ErrNotFound)
+}
+
 func TestClient_Writer_Integration(t *testing.T) {
 	url := SetupLocalSolNode(t)
 	privKey, err := solana.NewRandomPrivateKey()
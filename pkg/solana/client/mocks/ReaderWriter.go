@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	client "github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+
 	rpc "github.com/gagliardetto/solana-go/rpc"
 	mock "github.com/stretchr/testify/mock"
 
@@ -102,6 +104,52 @@ func (_m *ReaderWriter) GetFeeForMessage(msg string) (uint64, error) {
 	return r0, r1
 }
 
+// GetSignaturesForAddressWithOpts provides a mock function with given fields: ctx, account, opts
+func (_m *ReaderWriter) GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	ret := _m.Called(ctx, account, opts)
+
+	var r0 []*rpc.TransactionSignature
+	if rf, ok := ret.Get(0).(func(context.Context, solana.PublicKey, *rpc.GetSignaturesForAddressOpts) []*rpc.TransactionSignature); ok {
+		r0 = rf(ctx, account, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*rpc.TransactionSignature)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, solana.PublicKey, *rpc.GetSignaturesForAddressOpts) error); ok {
+		r1 = rf(ctx, account, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransaction provides a mock function with given fields: ctx, txSig, opts
+func (_m *ReaderWriter) GetTransaction(ctx context.Context, txSig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	ret := _m.Called(ctx, txSig, opts)
+
+	var r0 *rpc.GetTransactionResult
+	if rf, ok := ret.Get(0).(func(context.Context, solana.Signature, *client.GetTransactionOpts) *rpc.GetTransactionResult); ok {
+		r0 = rf(ctx, txSig, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rpc.GetTransactionResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, solana.Signature, *client.GetTransactionOpts) error); ok {
+		r1 = rf(ctx, txSig, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LatestBlockhash provides a mock function with given fields:
 func (_m *ReaderWriter) LatestBlockhash() (*rpc.GetLatestBlockhashResult, error) {
 	ret := _m.Called()
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// GetTransactionOpts extends rpc.GetTransactionOpts with the maxSupportedTransactionVersion parameter,
+// which the pinned solana-go release does not support
+type GetTransactionOpts struct {
+	Encoding   solana.EncodingType
+	Commitment rpc.CommitmentType
+	// MaxSupportedTransactionVersion is the highest transaction version to return,
+	// if nil the RPC fails on any versioned transaction
+	MaxSupportedTransactionVersion *uint64
+}
+
+// RPCCaller is an interface that allows raw calls to the solana rpc client
+type RPCCaller interface {
+	RPCCallForInto(ctx context.Context, out interface{}, method string, params []interface{}) error
+}
+
+// GetTransaction calls getTransaction through the raw rpc client to support versioned transactions
+// https://docs.solana.com/developing/clients/jsonrpc-api#gettransaction
+func GetTransaction(ctx context.Context, caller RPCCaller, txSig solana.Signature, opts *GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	params := []interface{}{txSig}
+	if opts != nil {
+		obj := rpc.M{}
+		if opts.Encoding != "" {
+			obj["encoding"] = opts.Encoding
+		}
+		if opts.Commitment != "" {
+			obj["commitment"] = opts.Commitment
+		}
+		if opts.MaxSupportedTransactionVersion != nil {
+			obj["maxSupportedTransactionVersion"] = *opts.MaxSupportedTransactionVersion
+		}
+		if len(obj) > 0 {
+			params = append(params, obj)
+		}
+	}
+	var out *rpc.GetTransactionResult
+	if err := caller.RPCCallForInto(ctx, &out, "getTransaction", params); err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return nil, rpc.ErrNotFound
+	}
+	return out, nil
+}
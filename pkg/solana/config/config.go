@@ -10,15 +10,37 @@ import (
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
 )
 
+// Default bounds of the offchain config parameters, values outside are reported as unhealthy.
+// They are also the defaults of solana.DefaultOffchainConfigBounds.
+const (
+	DefaultOffchainConfigMinDeltaRound    = time.Second
+	DefaultOffchainConfigMaxDeltaRound    = 5 * time.Minute
+	DefaultOffchainConfigMinDeltaProgress = 2 * time.Second
+	DefaultOffchainConfigMaxDeltaProgress = 10 * time.Minute
+	DefaultOffchainConfigMaxDuration      = 30 * time.Second // upper bound of every MaxDuration* parameter
+	DefaultOffchainConfigMaxAlphaPPB      = 100_000_000      // upper bound of the median deviation thresholds (10%), 0 is unbounded
+)
+
 // Global solana defaults.
 var defaultConfigSet = configSet{
-	BalancePollPeriod:   5 * time.Second, // poll period for balance monitoring
-	ConfirmPollPeriod:   time.Second,     // polling for tx confirmation
-	OCR2CachePollPeriod: time.Second,     // cache polling rate
-	OCR2CacheTTL:        time.Minute,     // stale cache deadline
-	TxTimeout:           time.Minute,     // transaction timeout
-	SkipPreflight:       true,            // to enable or disable preflight checks
-	Commitment:          rpc.CommitmentConfirmed,
+	BalancePollPeriod:        5 * time.Second, // poll period for balance monitoring
+	ConfirmPollPeriod:        time.Second,     // polling for tx confirmation
+	OCR2CachePollPeriod:      time.Second,     // cache polling rate
+	OCR2CacheTTL:             time.Minute,     // stale cache deadline
+	TxTimeout:                time.Minute,     // transaction timeout
+	SkipPreflight:            true,            // to enable or disable preflight checks
+	Commitment:               rpc.CommitmentConfirmed,
+	MinTransmitterBalance:    0,           // lamports the transmitter must retain after paying the transmit fee
+	BalanceWarnThreshold:     100_000_000, // lamports (0.1 SOL) below which the balance monitor warns
+	BalanceCriticalThreshold: 10_000_000,  // lamports (0.01 SOL) below which the relayer is unhealthy
+	VerifyReportSignatures:   false,       // verify report signatures against the on-chain oracle set before transmitting
+	// bounds of the offchain config parameters
+	OffchainConfigMinDeltaRound:    DefaultOffchainConfigMinDeltaRound,
+	OffchainConfigMaxDeltaRound:    DefaultOffchainConfigMaxDeltaRound,
+	OffchainConfigMinDeltaProgress: DefaultOffchainConfigMinDeltaProgress,
+	OffchainConfigMaxDeltaProgress: DefaultOffchainConfigMaxDeltaProgress,
+	OffchainConfigMaxDuration:      DefaultOffchainConfigMaxDuration,
+	OffchainConfigMaxAlphaPPB:      DefaultOffchainConfigMaxAlphaPPB,
 }
 
 type Config interface {
@@ -29,19 +51,40 @@ type Config interface {
 	TxTimeout() time.Duration
 	SkipPreflight() bool
 	Commitment() rpc.CommitmentType
+	MinTransmitterBalance() uint64
+	BalanceWarnThreshold() uint64
+	BalanceCriticalThreshold() uint64
+	VerifyReportSignatures() bool
+	OffchainConfigMinDeltaRound() time.Duration
+	OffchainConfigMaxDeltaRound() time.Duration
+	OffchainConfigMinDeltaProgress() time.Duration
+	OffchainConfigMaxDeltaProgress() time.Duration
+	OffchainConfigMaxDuration() time.Duration
+	OffchainConfigMaxAlphaPPB() uint64
 
 	// Update sets new chain config values.
 	Update(db.ChainCfg)
 }
 
 type configSet struct {
-	BalancePollPeriod   time.Duration
-	ConfirmPollPeriod   time.Duration
-	OCR2CachePollPeriod time.Duration
-	OCR2CacheTTL        time.Duration
-	TxTimeout           time.Duration
-	SkipPreflight       bool
-	Commitment          rpc.CommitmentType
+	BalancePollPeriod        time.Duration
+	ConfirmPollPeriod        time.Duration
+	OCR2CachePollPeriod      time.Duration
+	OCR2CacheTTL             time.Duration
+	TxTimeout                time.Duration
+	SkipPreflight            bool
+	Commitment               rpc.CommitmentType
+	MinTransmitterBalance    uint64
+	BalanceWarnThreshold     uint64
+	BalanceCriticalThreshold uint64
+	VerifyReportSignatures   bool
+
+	OffchainConfigMinDeltaRound    time.Duration
+	OffchainConfigMaxDeltaRound    time.Duration
+	OffchainConfigMinDeltaProgress time.Duration
+	OffchainConfigMaxDeltaProgress time.Duration
+	OffchainConfigMaxDuration      time.Duration
+	OffchainConfigMaxAlphaPPB      uint64
 }
 
 var _ Config = (*config)(nil)
@@ -148,3 +191,103 @@ func (c *config) Commitment() rpc.CommitmentType {
 	}
 	return c.defaults.Commitment
 }
+
+func (c *config) MinTransmitterBalance() uint64 {
+	c.chainMu.RLock()
+	ch := c.chain.MinTransmitterBalance
+	c.chainMu.RUnlock()
+	if ch.Valid && ch.Int64 >= 0 {
+		return uint64(ch.Int64)
+	}
+	return c.defaults.MinTransmitterBalance
+}
+
+func (c *config) BalanceWarnThreshold() uint64 {
+	c.chainMu.RLock()
+	ch := c.chain.BalanceWarnThreshold
+	c.chainMu.RUnlock()
+	if ch.Valid && ch.Int64 >= 0 {
+		return uint64(ch.Int64)
+	}
+	return c.defaults.BalanceWarnThreshold
+}
+
+func (c *config) BalanceCriticalThreshold() uint64 {
+	c.chainMu.RLock()
+	ch := c.chain.BalanceCriticalThreshold
+	c.chainMu.RUnlock()
+	if ch.Valid && ch.Int64 >= 0 {
+		return uint64(ch.Int64)
+	}
+	return c.defaults.BalanceCriticalThreshold
+}
+
+func (c *config) VerifyReportSignatures() bool {
+	c.chainMu.RLock()
+	ch := c.chain.VerifyReportSignatures
+	c.chainMu.RUnlock()
+	if ch.Valid {
+		return ch.Bool
+	}
+	return c.defaults.VerifyReportSignatures
+}
+
+func (c *config) OffchainConfigMinDeltaRound() time.Duration {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMinDeltaRound
+	c.chainMu.RUnlock()
+	if ch != nil {
+		return ch.Duration()
+	}
+	return c.defaults.OffchainConfigMinDeltaRound
+}
+
+func (c *config) OffchainConfigMaxDeltaRound() time.Duration {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMaxDeltaRound
+	c.chainMu.RUnlock()
+	if ch != nil {
+		return ch.Duration()
+	}
+	return c.defaults.OffchainConfigMaxDeltaRound
+}
+
+func (c *config) OffchainConfigMinDeltaProgress() time.Duration {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMinDeltaProgress
+	c.chainMu.RUnlock()
+	if ch != nil {
+		return ch.Duration()
+	}
+	return c.defaults.OffchainConfigMinDeltaProgress
+}
+
+func (c *config) OffchainConfigMaxDeltaProgress() time.Duration {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMaxDeltaProgress
+	c.chainMu.RUnlock()
+	if ch != nil {
+		return ch.Duration()
+	}
+	return c.defaults.OffchainConfigMaxDeltaProgress
+}
+
+func (c *config) OffchainConfigMaxDuration() time.Duration {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMaxDuration
+	c.chainMu.RUnlock()
+	if ch != nil {
+		return ch.Duration()
+	}
+	return c.defaults.OffchainConfigMaxDuration
+}
+
+func (c *config) OffchainConfigMaxAlphaPPB() uint64 {
+	c.chainMu.RLock()
+	ch := c.chain.OffchainConfigMaxAlphaPPB
+	c.chainMu.RUnlock()
+	if ch.Valid && ch.Int64 >= 0 {
+		return uint64(ch.Int64)
+	}
+	return c.defaults.OffchainConfigMaxAlphaPPB
+}
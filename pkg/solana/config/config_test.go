@@ -21,31 +21,64 @@ var (
 	testTxTimeout     = models.MustMakeDuration(5 * time.Minute)
 	testPreflight     = false
 	testCommitment    = "finalized"
+	testMinBalance    = int64(1000000)
+	testWarnBalance   = int64(2000000)
+	testCritBalance   = int64(3000000)
+	testVerifySigs    = true
+
+	testMinDeltaRound    = models.MustMakeDuration(2 * time.Second)
+	testMaxDeltaRound    = models.MustMakeDuration(3 * time.Minute)
+	testMinDeltaProgress = models.MustMakeDuration(4 * time.Second)
+	testMaxDeltaProgress = models.MustMakeDuration(5 * time.Minute)
+	testMaxDuration      = models.MustMakeDuration(6 * time.Second)
+	testMaxAlphaPPB      = int64(7000)
 )
 
 func TestConfig_ExpectedDefaults(t *testing.T) {
 	cfg := NewConfig(db.ChainCfg{}, logger.TestLogger(t))
 	configSet := configSet{
-		BalancePollPeriod:   cfg.BalancePollPeriod(),
-		ConfirmPollPeriod:   cfg.ConfirmPollPeriod(),
-		OCR2CachePollPeriod: cfg.OCR2CachePollPeriod(),
-		OCR2CacheTTL:        cfg.OCR2CacheTTL(),
-		TxTimeout:           cfg.TxTimeout(),
-		SkipPreflight:       cfg.SkipPreflight(),
-		Commitment:          cfg.Commitment(),
+		BalancePollPeriod:        cfg.BalancePollPeriod(),
+		ConfirmPollPeriod:        cfg.ConfirmPollPeriod(),
+		OCR2CachePollPeriod:      cfg.OCR2CachePollPeriod(),
+		OCR2CacheTTL:             cfg.OCR2CacheTTL(),
+		TxTimeout:                cfg.TxTimeout(),
+		SkipPreflight:            cfg.SkipPreflight(),
+		Commitment:               cfg.Commitment(),
+		MinTransmitterBalance:    cfg.MinTransmitterBalance(),
+		BalanceWarnThreshold:     cfg.BalanceWarnThreshold(),
+		BalanceCriticalThreshold: cfg.BalanceCriticalThreshold(),
+		VerifyReportSignatures:   cfg.VerifyReportSignatures(),
+
+		OffchainConfigMinDeltaRound:    cfg.OffchainConfigMinDeltaRound(),
+		OffchainConfigMaxDeltaRound:    cfg.OffchainConfigMaxDeltaRound(),
+		OffchainConfigMinDeltaProgress: cfg.OffchainConfigMinDeltaProgress(),
+		OffchainConfigMaxDeltaProgress: cfg.OffchainConfigMaxDeltaProgress(),
+		OffchainConfigMaxDuration:      cfg.OffchainConfigMaxDuration(),
+		OffchainConfigMaxAlphaPPB:      cfg.OffchainConfigMaxAlphaPPB(),
 	}
 	assert.Equal(t, defaultConfigSet, configSet)
 }
 
 func TestConfig_NewConfig(t *testing.T) {
 	dbCfg := db.ChainCfg{
-		BalancePollPeriod:   &testBalancePoll,
-		ConfirmPollPeriod:   &testConfirmPeriod,
-		OCR2CachePollPeriod: &testCachePeriod,
-		OCR2CacheTTL:        &testTTL,
-		TxTimeout:           &testTxTimeout,
-		SkipPreflight:       null.BoolFrom(testPreflight),
-		Commitment:          null.StringFrom(testCommitment),
+		BalancePollPeriod:        &testBalancePoll,
+		ConfirmPollPeriod:        &testConfirmPeriod,
+		OCR2CachePollPeriod:      &testCachePeriod,
+		OCR2CacheTTL:             &testTTL,
+		TxTimeout:                &testTxTimeout,
+		SkipPreflight:            null.BoolFrom(testPreflight),
+		Commitment:               null.StringFrom(testCommitment),
+		MinTransmitterBalance:    null.IntFrom(testMinBalance),
+		BalanceWarnThreshold:     null.IntFrom(testWarnBalance),
+		BalanceCriticalThreshold: null.IntFrom(testCritBalance),
+		VerifyReportSignatures:   null.BoolFrom(testVerifySigs),
+
+		OffchainConfigMinDeltaRound:    &testMinDeltaRound,
+		OffchainConfigMaxDeltaRound:    &testMaxDeltaRound,
+		OffchainConfigMinDeltaProgress: &testMinDeltaProgress,
+		OffchainConfigMaxDeltaProgress: &testMaxDeltaProgress,
+		OffchainConfigMaxDuration:      &testMaxDuration,
+		OffchainConfigMaxAlphaPPB:      null.IntFrom(testMaxAlphaPPB),
 	}
 	cfg := NewConfig(dbCfg, logger.TestLogger(t))
 	assert.Equal(t, testBalancePoll.Duration(), cfg.BalancePollPeriod())
@@ -55,18 +88,39 @@ func TestConfig_NewConfig(t *testing.T) {
 	assert.Equal(t, testTxTimeout.Duration(), cfg.TxTimeout())
 	assert.Equal(t, testPreflight, cfg.SkipPreflight())
 	assert.Equal(t, rpc.CommitmentType(testCommitment), cfg.Commitment())
+	assert.Equal(t, uint64(testMinBalance), cfg.MinTransmitterBalance())
+	assert.Equal(t, uint64(testWarnBalance), cfg.BalanceWarnThreshold())
+	assert.Equal(t, uint64(testCritBalance), cfg.BalanceCriticalThreshold())
+	assert.Equal(t, testVerifySigs, cfg.VerifyReportSignatures())
+	assert.Equal(t, testMinDeltaRound.Duration(), cfg.OffchainConfigMinDeltaRound())
+	assert.Equal(t, testMaxDeltaRound.Duration(), cfg.OffchainConfigMaxDeltaRound())
+	assert.Equal(t, testMinDeltaProgress.Duration(), cfg.OffchainConfigMinDeltaProgress())
+	assert.Equal(t, testMaxDeltaProgress.Duration(), cfg.OffchainConfigMaxDeltaProgress())
+	assert.Equal(t, testMaxDuration.Duration(), cfg.OffchainConfigMaxDuration())
+	assert.Equal(t, uint64(testMaxAlphaPPB), cfg.OffchainConfigMaxAlphaPPB())
 }
 
 func TestConfig_Update(t *testing.T) {
 	cfg := NewConfig(db.ChainCfg{}, logger.TestLogger(t))
 	dbCfg := db.ChainCfg{
-		BalancePollPeriod:   &testBalancePoll,
-		ConfirmPollPeriod:   &testConfirmPeriod,
-		OCR2CachePollPeriod: &testCachePeriod,
-		OCR2CacheTTL:        &testTTL,
-		TxTimeout:           &testTxTimeout,
-		SkipPreflight:       null.BoolFrom(testPreflight),
-		Commitment:          null.StringFrom(testCommitment),
+		BalancePollPeriod:        &testBalancePoll,
+		ConfirmPollPeriod:        &testConfirmPeriod,
+		OCR2CachePollPeriod:      &testCachePeriod,
+		OCR2CacheTTL:             &testTTL,
+		TxTimeout:                &testTxTimeout,
+		SkipPreflight:            null.BoolFrom(testPreflight),
+		Commitment:               null.StringFrom(testCommitment),
+		MinTransmitterBalance:    null.IntFrom(testMinBalance),
+		BalanceWarnThreshold:     null.IntFrom(testWarnBalance),
+		BalanceCriticalThreshold: null.IntFrom(testCritBalance),
+		VerifyReportSignatures:   null.BoolFrom(testVerifySigs),
+
+		OffchainConfigMinDeltaRound:    &testMinDeltaRound,
+		OffchainConfigMaxDeltaRound:    &testMaxDeltaRound,
+		OffchainConfigMinDeltaProgress: &testMinDeltaProgress,
+		OffchainConfigMaxDeltaProgress: &testMaxDeltaProgress,
+		OffchainConfigMaxDuration:      &testMaxDuration,
+		OffchainConfigMaxAlphaPPB:      null.IntFrom(testMaxAlphaPPB),
 	}
 	cfg.Update(dbCfg)
 	assert.Equal(t, testBalancePoll.Duration(), cfg.BalancePollPeriod())
@@ -76,6 +130,16 @@ func TestConfig_Update(t *testing.T) {
 	assert.Equal(t, testTxTimeout.Duration(), cfg.TxTimeout())
 	assert.Equal(t, testPreflight, cfg.SkipPreflight())
 	assert.Equal(t, rpc.CommitmentType(testCommitment), cfg.Commitment())
+	assert.Equal(t, uint64(testMinBalance), cfg.MinTransmitterBalance())
+	assert.Equal(t, uint64(testWarnBalance), cfg.BalanceWarnThreshold())
+	assert.Equal(t, uint64(testCritBalance), cfg.BalanceCriticalThreshold())
+	assert.Equal(t, testVerifySigs, cfg.VerifyReportSignatures())
+	assert.Equal(t, testMinDeltaRound.Duration(), cfg.OffchainConfigMinDeltaRound())
+	assert.Equal(t, testMaxDeltaRound.Duration(), cfg.OffchainConfigMaxDeltaRound())
+	assert.Equal(t, testMinDeltaProgress.Duration(), cfg.OffchainConfigMinDeltaProgress())
+	assert.Equal(t, testMaxDeltaProgress.Duration(), cfg.OffchainConfigMaxDeltaProgress())
+	assert.Equal(t, testMaxDuration.Duration(), cfg.OffchainConfigMaxDuration())
+	assert.Equal(t, uint64(testMaxAlphaPPB), cfg.OffchainConfigMaxAlphaPPB())
 }
 
 func TestConfig_CommitmentFallback(t *testing.T) {
@@ -0,0 +1,86 @@
+package solana
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// maxConfigHistory is the number of config snapshots kept for LatestConfig lookups
+const maxConfigHistory = 8
+
+// configHistory keeps snapshots of the configs observed in the state account by digest,
+// and the block each config was set in
+type configHistory struct {
+	lock    sync.RWMutex
+	configs map[types.ConfigDigest]types.ContractConfig
+	blocks  map[uint64]types.ConfigDigest
+	order   []types.ConfigDigest // oldest first
+}
+
+func newConfigHistory() *configHistory {
+	return &configHistory{
+		configs: map[types.ConfigDigest]types.ContractConfig{},
+		blocks:  map[uint64]types.ConfigDigest{},
+	}
+}
+
+// Add records the config set in block, evicting the oldest snapshot once full
+func (h *configHistory) Add(block uint64, cfg types.ContractConfig) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.blocks[block] = cfg.ConfigDigest
+	if _, ok := h.configs[cfg.ConfigDigest]; ok {
+		return
+	}
+	h.configs[cfg.ConfigDigest] = cfg
+	h.order = append(h.order, cfg.ConfigDigest)
+	if len(h.order) <= maxConfigHistory {
+		return
+	}
+	evicted := h.order[0]
+	h.order = h.order[1:]
+	delete(h.configs, evicted)
+	for block, digest := range h.blocks {
+		if digest == evicted {
+			delete(h.blocks, block)
+		}
+	}
+}
+
+// SetBlock records the digest of the config set in block
+func (h *configHistory) SetBlock(block uint64, digest types.ConfigDigest) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.blocks[block] = digest
+}
+
+// Digest returns the digest of the config set in block
+func (h *configHistory) Digest(block uint64) (types.ConfigDigest, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	digest, ok := h.blocks[block]
+	return digest, ok
+}
+
+// Get returns the snapshot of the config with the given digest
+func (h *configHistory) Get(digest types.ConfigDigest) (types.ContractConfig, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	cfg, ok := h.configs[digest]
+	return cfg, ok
+}
+
+// matchesSetConfig checks a config snapshot against the F and signers of its SetConfig event
+func matchesSetConfig(cfg types.ContractConfig, f uint8, signers [][20]byte) bool {
+	if cfg.F != f || len(cfg.Signers) != len(signers) {
+		return false
+	}
+	for i, s := range signers {
+		if !bytes.Equal(cfg.Signers[i], s[:]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,186 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+)
+
+// HistoryReader reads transactions and the transaction history of accounts, it is satisfied by the relay client
+type HistoryReader interface {
+	TransactionReader
+	GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error)
+}
+
+// proposedConfig is the config written to a proposal account
+type proposedConfig struct {
+	offchainConfigVersion uint64
+	offchainConfig        []byte
+	oracles               []ocr_2.NewOracle // sorted by signer
+	f                     uint8
+}
+
+// ConfigFromProposal rebuilds the config with the given digest, accepted by the setConfigTx transaction.
+// The proposal account is closed once accepted: the config is replayed from the instructions in the proposal
+// transaction history, and the config count is recovered by matching the digest, up to the count of state.
+// The answer bounds are read from state, they never change after the program is initialized.
+func ConfigFromProposal(ctx context.Context, reader HistoryReader, programID, stateID solana.PublicKey, state State, setConfigTx solana.Signature, digest types.ConfigDigest, commitment rpc.CommitmentType) (types.ContractConfig, error) {
+	proposalID, err := acceptedProposal(ctx, reader, programID, stateID, setConfigTx, commitment)
+	if err != nil {
+		return types.ContractConfig{}, err
+	}
+	proposal, err := replayProposal(ctx, reader, programID, proposalID, setConfigTx, commitment)
+	if err != nil {
+		return types.ContractConfig{}, err
+	}
+
+	cfg := types.ContractConfig{
+		ConfigDigest:          digest,
+		F:                     proposal.f,
+		OffchainConfigVersion: proposal.offchainConfigVersion,
+		OffchainConfig:        proposal.offchainConfig,
+	}
+	for _, o := range proposal.oracles {
+		o := o
+		cfg.Signers = append(cfg.Signers, o.Signer[:])
+		cfg.Transmitters = append(cfg.Transmitters, types.Account(o.Transmitter.String()))
+	}
+	if cfg.OnchainConfig, err = onchainConfigFromState(state); err != nil {
+		return types.ContractConfig{}, err
+	}
+
+	digester := OffchainConfigDigester{ProgramID: programID, StateID: stateID}
+	for count := uint64(state.Config.ConfigCount); count > 0; count-- {
+		cfg.ConfigCount = count
+		d, err := digester.ConfigDigest(cfg)
+		if err != nil {
+			return types.ContractConfig{}, errors.Wrap(err, "error in ConfigFromProposal.ConfigDigest")
+		}
+		if d == digest {
+			return cfg, nil
+		}
+	}
+	return types.ContractConfig{}, fmt.Errorf("config replayed from proposal %s does not match config digest %s", proposalID, digest)
+}
+
+// acceptedProposal returns the proposal account of the accept_proposal instruction of state in txSig
+func acceptedProposal(ctx context.Context, reader TransactionReader, programID, stateID solana.PublicKey, txSig solana.Signature, commitment rpc.CommitmentType) (solana.PublicKey, error) {
+	_, keys, instructions, err := fetchTransaction(ctx, reader, txSig, commitment)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	for _, ix := range programInstructions(keys, instructions, programID) {
+		// accounts: state, proposal, receiver, authority, token_vault, vault_authority, token_program
+		if len(ix.Accounts) < 2 || !bytes.HasPrefix(ix.Data, ocr_2.Instruction_AcceptProposal[:]) {
+			continue
+		}
+		if int(ix.Accounts[0]) < len(keys) && int(ix.Accounts[1]) < len(keys) && keys[ix.Accounts[0]].Equals(stateID) {
+			return keys[ix.Accounts[1]], nil
+		}
+	}
+	return solana.PublicKey{}, fmt.Errorf("no accept_proposal instruction for state %s found in transaction %s", stateID, txSig)
+}
+
+// replayProposal replays the proposal instructions sent before the acceptTx transaction,
+// back to the create_proposal instruction initializing the proposal
+func replayProposal(ctx context.Context, reader HistoryReader, programID, proposalID solana.PublicKey, acceptTx solana.Signature, commitment rpc.CommitmentType) (proposedConfig, error) {
+	// instructions of each transaction, newest first
+	var history [][]ocr_2.Instruction
+	limit := events.MaxPageSize
+	for before, created := acceptTx, false; !created; {
+		sigs, err := reader.GetSignaturesForAddressWithOpts(ctx, proposalID, &rpc.GetSignaturesForAddressOpts{
+			Before:     before,
+			Limit:      &limit,
+			Commitment: commitment,
+		})
+		if err != nil {
+			return proposedConfig{}, fmt.Errorf("failed to fetch signatures for proposal %s: %w", proposalID, err)
+		}
+		if len(sigs) == 0 {
+			return proposedConfig{}, fmt.Errorf("no create_proposal instruction found for proposal %s", proposalID)
+		}
+		for _, sig := range sigs {
+			if sig.Err != nil {
+				continue // failed transactions did not modify the proposal
+			}
+			var ixs []ocr_2.Instruction
+			ixs, created, err = proposalInstructions(ctx, reader, programID, proposalID, sig.Signature, commitment)
+			if err != nil {
+				return proposedConfig{}, err
+			}
+			history = append(history, ixs)
+			if created {
+				break
+			}
+		}
+		before = sigs[len(sigs)-1].Signature
+	}
+
+	var p proposedConfig
+	for i := len(history) - 1; i >= 0; i-- {
+		for _, ix := range history[i] {
+			switch impl := ix.Impl.(type) {
+			case *ocr_2.CreateProposal:
+				p = proposedConfig{offchainConfigVersion: *impl.OffchainConfigVersion}
+			case *ocr_2.WriteOffchainConfig:
+				p.offchainConfig = append(p.offchainConfig, *impl.OffchainConfig...)
+			case *ocr_2.ProposeConfig:
+				p.oracles = append([]ocr_2.NewOracle{}, *impl.NewOracles...)
+				sort.Slice(p.oracles, func(i, j int) bool {
+					return bytes.Compare(p.oracles[i].Signer[:], p.oracles[j].Signer[:]) < 0
+				})
+				p.f = *impl.F
+			}
+		}
+	}
+	return p, nil
+}
+
+// proposalInstructions decodes the instructions of txSig writing the config of the proposal,
+// created reports whether the transaction initialized the proposal
+func proposalInstructions(ctx context.Context, reader TransactionReader, programID, proposalID solana.PublicKey, txSig solana.Signature, commitment rpc.CommitmentType) (out []ocr_2.Instruction, created bool, err error) {
+	_, keys, instructions, err := fetchTransaction(ctx, reader, txSig, commitment)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, ix := range programInstructions(keys, instructions, programID) {
+		// accounts: proposal, authority
+		if len(ix.Accounts) == 0 || int(ix.Accounts[0]) >= len(keys) || !keys[ix.Accounts[0]].Equals(proposalID) {
+			continue
+		}
+		if !bytes.HasPrefix(ix.Data, ocr_2.Instruction_CreateProposal[:]) &&
+			!bytes.HasPrefix(ix.Data, ocr_2.Instruction_WriteOffchainConfig[:]) &&
+			!bytes.HasPrefix(ix.Data, ocr_2.Instruction_ProposeConfig[:]) {
+			continue
+		}
+		decoded, err := ocr_2.DecodeInstruction(nil, ix.Data)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decode proposal instruction in transaction %s: %w", txSig, err)
+		}
+		if _, ok := decoded.Impl.(*ocr_2.CreateProposal); ok {
+			created = true
+		}
+		out = append(out, *decoded)
+	}
+	return out, created, nil
+}
+
+// programInstructions returns the instructions invoking programID
+func programInstructions(keys []solana.PublicKey, instructions []solana.CompiledInstruction, programID solana.PublicKey) []solana.CompiledInstruction {
+	var out []solana.CompiledInstruction
+	for _, ix := range instructions {
+		if int(ix.ProgramIDIndex) < len(keys) && keys[ix.ProgramIDIndex].Equals(programID) {
+			out = append(out, ix)
+		}
+	}
+	return out
+}
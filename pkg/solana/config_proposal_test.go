@@ -0,0 +1,253 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"sort"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+)
+
+type testLedgerTx struct {
+	sig      solana.Signature
+	accounts []solana.PublicKey
+	result   *rpc.GetTransactionResult
+}
+
+// testLedger serves signed transactions and the history of the accounts they reference, the slot is the transaction index
+type testLedger struct {
+	t         *testing.T
+	programID solana.PublicKey
+	payer     solana.PrivateKey
+	txs       []testLedgerTx
+}
+
+func newTestLedger(t *testing.T, programID solana.PublicKey) *testLedger {
+	payer, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	return &testLedger{t: t, programID: programID, payer: payer}
+}
+
+// send adds a transaction of generated instructions bound to the ledger program, emitting evts
+func (l *testLedger) send(failed bool, ixs []solana.Instruction, evts ...events.Event) solana.Signature {
+	var bound []solana.Instruction
+	for _, ix := range ixs {
+		data, err := ix.Data()
+		require.NoError(l.t, err)
+		bound = append(bound, solana.NewInstruction(l.programID, ix.Accounts(), data))
+	}
+	tx, err := solana.NewTransaction(bound, solana.Hash{byte(len(l.txs))}, solana.TransactionPayer(l.payer.PublicKey()))
+	require.NoError(l.t, err)
+	_, err = tx.Sign(func(key solana.PublicKey) *solana.PrivateKey {
+		if key.Equals(l.payer.PublicKey()) {
+			return &l.payer
+		}
+		return nil
+	})
+	require.NoError(l.t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(l.t, err)
+
+	res := testTxResult(l.t, uint64(len(l.txs)), raw)
+	res.Meta.LogMessages = []string{"Program " + l.programID.String() + " invoke [1]"}
+	for _, e := range evts {
+		d := events.Discriminator(e.EventName())
+		buf := bytes.NewBuffer(d[:])
+		require.NoError(l.t, bin.NewBorshEncoder(buf).Encode(e))
+		res.Meta.LogMessages = append(res.Meta.LogMessages, "Program data: "+base64.StdEncoding.EncodeToString(buf.Bytes()))
+	}
+	res.Meta.LogMessages = append(res.Meta.LogMessages, "Program "+l.programID.String()+" success")
+	if failed {
+		res.Meta.Err = "failed"
+	}
+	l.txs = append(l.txs, testLedgerTx{sig: tx.Signatures[0], accounts: tx.Message.AccountKeys, result: res})
+	return tx.Signatures[0]
+}
+
+func (l *testLedger) GetSignaturesForAddressWithOpts(_ context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	var out []*rpc.TransactionSignature
+	started := opts.Before.IsZero()
+	for i := len(l.txs) - 1; i >= 0 && len(out) < *opts.Limit; i-- {
+		tx := l.txs[i]
+		if !started {
+			started = tx.sig == opts.Before
+			continue
+		}
+		if tx.sig == opts.Until {
+			break
+		}
+		for _, a := range tx.accounts {
+			if a.Equals(account) {
+				out = append(out, &rpc.TransactionSignature{Signature: tx.sig, Slot: uint64(i), Err: tx.result.Meta.Err})
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (l *testLedger) GetTransaction(_ context.Context, txSig solana.Signature, _ *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	for _, tx := range l.txs {
+		if tx.sig == txSig {
+			return tx.result, nil
+		}
+	}
+	return nil, rpc.ErrNotFound
+}
+
+func (l *testLedger) GetAccountInfoWithOpts(context.Context, solana.PublicKey, *rpc.GetAccountInfoOpts) (*rpc.GetAccountInfoResult, error) {
+	return nil, rpc.ErrNotFound
+}
+
+// testOracles returns the proposal oracles of signers, not sorted
+func testOracles(signers ...[20]byte) []ocr_2.NewOracle {
+	var oracles []ocr_2.NewOracle
+	for _, s := range signers {
+		oracles = append(oracles, ocr_2.NewOracle{Signer: s, Transmitter: solana.NewWallet().PublicKey()})
+	}
+	return oracles
+}
+
+// testAcceptedProposal writes a proposal of oracles to the ledger and accepts it in the returned transaction,
+// the returned config is the accepted config with the given count
+func testAcceptedProposal(t *testing.T, l *testLedger, stateID solana.PublicKey, state State, count uint64, oracles []ocr_2.NewOracle) (solana.Signature, types.ContractConfig) {
+	proposal, authority := solana.NewWallet().PublicKey(), l.payer.PublicKey()
+	offchainConfig := bytes.Repeat([]byte{7}, 300)
+	const offchainConfigVersion = 2
+
+	l.send(false, []solana.Instruction{
+		ocr_2.NewCreateProposalInstruction(offchainConfigVersion, proposal, authority).Build(),
+		ocr_2.NewWriteOffchainConfigInstruction(offchainConfig[:200], proposal, authority).Build(),
+	})
+	l.send(false, []solana.Instruction{ocr_2.NewProposeConfigInstruction(oracles[:2], 1, proposal, authority).Build()})
+	l.send(true, []solana.Instruction{ocr_2.NewWriteOffchainConfigInstruction([]byte{0xff}, proposal, authority).Build()})
+	l.send(false, []solana.Instruction{ocr_2.NewWriteOffchainConfigInstruction(offchainConfig[200:], proposal, authority).Build()})
+	l.send(false, []solana.Instruction{ocr_2.NewProposeConfigInstruction(oracles, 1, proposal, authority).Build()})
+	l.send(false, []solana.Instruction{ocr_2.NewFinalizeProposalInstruction(proposal, authority).Build()})
+
+	onchainConfig, err := onchainConfigFromState(state)
+	require.NoError(t, err)
+	cfg := types.ContractConfig{
+		ConfigCount:           count,
+		F:                     1,
+		OnchainConfig:         onchainConfig,
+		OffchainConfigVersion: offchainConfigVersion,
+		OffchainConfig:        offchainConfig,
+	}
+	sorted := append([]ocr_2.NewOracle{}, oracles...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i].Signer[:], sorted[j].Signer[:]) < 0 })
+	for _, o := range sorted {
+		o := o
+		cfg.Signers = append(cfg.Signers, o.Signer[:])
+		cfg.Transmitters = append(cfg.Transmitters, types.Account(o.Transmitter.String()))
+	}
+	cfg.ConfigDigest, err = OffchainConfigDigester{ProgramID: l.programID, StateID: stateID}.ConfigDigest(cfg)
+	require.NoError(t, err)
+
+	var signers [][20]byte
+	for _, s := range cfg.Signers {
+		var signer [20]byte
+		copy(signer[:], s)
+		signers = append(signers, signer)
+	}
+	sig := l.send(false, []solana.Instruction{
+		ocr_2.NewAcceptProposalInstruction(make([]byte, 32), stateID, proposal, authority, authority,
+			solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.TokenProgramID).Build(),
+	}, &events.SetConfig{ConfigDigest: cfg.ConfigDigest, F: cfg.F, Signers: signers})
+	return sig, cfg
+}
+
+func TestConfigFromProposal(t *testing.T) {
+	ctx := context.Background()
+	_, state := testOracleIdentities(t, 4)
+	state.Config.ConfigCount = 5
+	programID, stateID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	l := newTestLedger(t, programID)
+
+	// a previous proposal of the same authority
+	testAcceptedProposal(t, l, stateID, state, 2, testOracles([20]byte{1}, [20]byte{2}, [20]byte{3}, [20]byte{4}))
+	acceptTx, expected := testAcceptedProposal(t, l, stateID, state, 3, testOracles([20]byte{3}, [20]byte{1}, [20]byte{4}, [20]byte{2}))
+
+	cfg, err := ConfigFromProposal(ctx, l, programID, stateID, state, acceptTx, expected.ConfigDigest, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, expected, cfg)
+
+	// digest not matching the replayed config
+	_, err = ConfigFromProposal(ctx, l, programID, stateID, state, acceptTx, types.ConfigDigest{1}, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+
+	// config count above the state count
+	state.Config.ConfigCount = 2
+	_, err = ConfigFromProposal(ctx, l, programID, stateID, state, acceptTx, expected.ConfigDigest, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+
+	// not an accept_proposal transaction of the state
+	_, err = ConfigFromProposal(ctx, l, programID, solana.NewWallet().PublicKey(), state, acceptTx, expected.ConfigDigest, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+	_, err = ConfigFromProposal(ctx, l, programID, stateID, state, l.txs[0].sig, expected.ConfigDigest, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+}
+
+func TestLatestConfig_Rebuild(t *testing.T) {
+	ctx := context.Background()
+	_, state := testOracleIdentities(t, 4)
+	state.Config.ConfigCount = 4
+	state.Config.LatestConfigDigest = [32]byte{9}
+	state.Config.LatestConfigBlockNumber = 100
+
+	lggr := logger.TestLogger(t)
+	reader := new(mocks.ReaderWriter)
+	tracker := NewTracker(OCR2Spec{StateID: solana.NewWallet().PublicKey()}, config.NewConfig(db.ChainCfg{}, lggr), reader, nil, nil, lggr)
+	l := newTestLedger(t, tracker.ProgramID)
+	tracker.events = events.NewFetcher(l, tracker.ProgramID, tracker.StateID, rpc.CommitmentConfirmed, lggr)
+	reader.On("GetTransaction", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, sig solana.Signature, opts *client.GetTransactionOpts) *rpc.GetTransactionResult {
+			res, _ := l.GetTransaction(ctx, sig, opts)
+			return res
+		},
+		func(ctx context.Context, sig solana.Signature, opts *client.GetTransactionOpts) error {
+			_, err := l.GetTransaction(ctx, sig, opts)
+			return err
+		},
+	)
+	reader.On("GetSignaturesForAddressWithOpts", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) []*rpc.TransactionSignature {
+			sigs, _ := l.GetSignaturesForAddressWithOpts(ctx, account, opts)
+			return sigs
+		},
+		nil,
+	)
+
+	// config replaced before the tracker started
+	_, expected := testAcceptedProposal(t, l, tracker.StateID, state, 3, testOracles([20]byte{3}, [20]byte{1}, [20]byte{4}, [20]byte{2}))
+	expectStateReads(t, reader, tracker.StateID, state, true)
+	require.NoError(t, tracker.fetchState(ctx))
+
+	block := uint64(len(l.txs) - 1)
+	cfg, err := tracker.LatestConfig(ctx, block)
+	require.NoError(t, err)
+	assert.Equal(t, expected, cfg)
+
+	// snapshot of the rebuilt config
+	digest, ok := tracker.configs.Digest(block)
+	assert.True(t, ok)
+	assert.Equal(t, expected.ConfigDigest, digest)
+	_, ok = tracker.configs.Get(expected.ConfigDigest)
+	assert.True(t, ok)
+}
@@ -2,9 +2,14 @@ package solana
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
 )
 
 func (c *ContractTracker) Notify() <-chan struct{} {
@@ -31,11 +36,7 @@ func ConfigFromState(state State) (types.ContractConfig, error) {
 		accounts = append(accounts, types.Account(o.Transmitter.String()))
 	}
 
-	onchainConfigStruct := median.OnchainConfig{
-		Min: state.Config.MinAnswer.BigInt(),
-		Max: state.Config.MaxAnswer.BigInt(),
-	}
-	onchainConfig, err := onchainConfigStruct.Encode()
+	onchainConfig, err := onchainConfigFromState(state)
 	if err != nil {
 		return types.ContractConfig{}, err
 	}
@@ -56,13 +57,81 @@ func ConfigFromState(state State) (types.ContractConfig, error) {
 	}, nil
 }
 
-// LatestConfig returns the latest configuration.
+// onchainConfigFromState encodes the answer bounds of state, which are set once when the program is initialized
+func onchainConfigFromState(state State) ([]byte, error) {
+	onchainConfig := median.OnchainConfig{
+		Min: state.Config.MinAnswer.BigInt(),
+		Max: state.Config.MaxAnswer.BigInt(),
+	}
+	return onchainConfig.Encode()
+}
+
+// LatestConfig returns the configuration set in changedInBlock. The cached state is refreshed if it predates
+// changedInBlock, configs replaced since are read from the snapshots of previously observed states
+// or rebuilt from their proposal.
 func (c *ContractTracker) LatestConfig(ctx context.Context, changedInBlock uint64) (types.ContractConfig, error) {
 	state, err := c.ReadState()
+	if err != nil || state.Config.LatestConfigBlockNumber < changedInBlock {
+		if err = c.fetchState(ctx); err != nil {
+			return types.ContractConfig{}, errors.Wrap(err, "error in LatestConfig.fetchState")
+		}
+		if state, err = c.ReadState(); err != nil {
+			return types.ContractConfig{}, err
+		}
+	}
+	switch {
+	case state.Config.LatestConfigBlockNumber == changedInBlock:
+		return ConfigFromState(state)
+	case state.Config.LatestConfigBlockNumber < changedInBlock:
+		return types.ContractConfig{}, fmt.Errorf("error in LatestConfig: latest config was set in block %d, before requested block %d", state.Config.LatestConfigBlockNumber, changedInBlock)
+	}
+	return c.historicalConfig(ctx, changedInBlock)
+}
+
+// historicalConfig returns the snapshot of a config replaced since, the config digest is looked up
+// from the SetConfig event emitted in changedInBlock if the block was not observed.
+// Configs without a snapshot, such as those replaced before a restart, are rebuilt from their proposal.
+func (c *ContractTracker) historicalConfig(ctx context.Context, changedInBlock uint64) (types.ContractConfig, error) {
+	if digest, ok := c.configs.Digest(changedInBlock); ok {
+		if cfg, ok := c.configs.Get(digest); ok {
+			return cfg, nil
+		}
+	}
+
+	txs, err := c.events.AtSlot(ctx, changedInBlock)
 	if err != nil {
-		return types.ContractConfig{}, err
+		return types.ContractConfig{}, errors.Wrap(err, "error in LatestConfig.AtSlot")
+	}
+	var setConfig *events.SetConfig
+	var setConfigTx solana.Signature
+	for _, tx := range txs {
+		for _, e := range tx.Events {
+			if sc, ok := e.(*events.SetConfig); ok {
+				setConfig, setConfigTx = sc, tx.Signature
+			}
+		}
+	}
+	if setConfig == nil {
+		return types.ContractConfig{}, fmt.Errorf("error in LatestConfig: no SetConfig event found in block %d", changedInBlock)
+	}
+
+	cfg, ok := c.configs.Get(setConfig.ConfigDigest)
+	if !ok {
+		state, err := c.ReadState()
+		if err != nil {
+			return types.ContractConfig{}, err
+		}
+		cfg, err = ConfigFromProposal(ctx, c.reader, c.ProgramID, c.StateID, state, setConfigTx, setConfig.ConfigDigest, c.cfg.Commitment())
+		if err != nil {
+			return types.ContractConfig{}, errors.Wrapf(err, "error in LatestConfig: config %x set in block %d is no longer available", setConfig.ConfigDigest, changedInBlock)
+		}
+		c.configs.Add(changedInBlock, cfg)
+	}
+	if !matchesSetConfig(cfg, setConfig.F, setConfig.Signers) {
+		return types.ContractConfig{}, fmt.Errorf("error in LatestConfig: config %x does not match its SetConfig event", setConfig.ConfigDigest)
 	}
-	return ConfigFromState(state)
+	c.configs.SetBlock(changedInBlock, setConfig.ConfigDigest)
+	return cfg, nil
 }
 
 // LatestBlockHeight returns the height of the most recent block in the chain.
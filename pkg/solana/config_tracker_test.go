@@ -5,9 +5,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
 )
 
 func TestLatestBlockHeight(t *testing.T) {
@@ -26,3 +37,86 @@ func TestLatestBlockHeight(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, h > 0)
 }
+
+func TestLatestConfig_History(t *testing.T) {
+	_, state := testOracleIdentities(t, 4)
+	state.Config.F = 1
+	lggr := logger.TestLogger(t)
+	reader := new(mocks.ReaderWriter)
+	tracker := NewTracker(OCR2Spec{StateID: solana.NewWallet().PublicKey()}, config.NewConfig(db.ChainCfg{}, lggr), reader, nil, nil, lggr)
+	setState := func(digest byte, block uint64) {
+		full := state.Config.LatestConfigDigest != [32]byte{digest}
+		state.Config.LatestConfigDigest = [32]byte{digest}
+		state.Config.LatestConfigBlockNumber = block
+		expectStateReads(t, reader, tracker.StateID, state, full)
+	}
+	ctx := context.Background()
+
+	setState(1, 10)
+	require.NoError(t, tracker.fetchState(ctx))
+	cfg, err := tracker.LatestConfig(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, types.ConfigDigest{1}, cfg.ConfigDigest)
+
+	// cached state predates the requested block
+	setState(2, 20)
+	cfg, err = tracker.LatestConfig(ctx, 20)
+	require.NoError(t, err)
+	assert.Equal(t, types.ConfigDigest{2}, cfg.ConfigDigest)
+
+	// replaced config from its snapshot
+	cfg, err = tracker.LatestConfig(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, types.ConfigDigest{1}, cfg.ConfigDigest)
+
+	// requested block after the latest config
+	setState(2, 20)
+	_, err = tracker.LatestConfig(ctx, 30)
+	assert.Error(t, err)
+	reader.AssertExpectations(t)
+
+	// unobserved blocks are resolved through SetConfig events
+	var signers [][20]byte
+	for _, o := range state.Oracles.Raw[:state.Oracles.Len] {
+		signers = append(signers, o.Signer.Key)
+	}
+	h := &testHistory{programID: tracker.ProgramID}
+	h.add(t, time.Now())                                                                       // slot 0
+	h.add(t, time.Now(), &events.SetConfig{ConfigDigest: [32]byte{1}, F: 1, Signers: signers}) // slot 1
+	h.add(t, time.Now(), &events.SetConfig{ConfigDigest: [32]byte{1}, F: 2, Signers: signers}) // slot 2
+	h.add(t, time.Now(), &events.SetConfig{ConfigDigest: [32]byte{3}, F: 1, Signers: signers}) // slot 3
+	tracker.events = events.NewFetcher(h, tracker.ProgramID, tracker.StateID, rpc.CommitmentConfirmed, lggr)
+
+	cfg, err = tracker.LatestConfig(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.ConfigDigest{1}, cfg.ConfigDigest)
+	digest, ok := tracker.configs.Digest(1)
+	assert.True(t, ok)
+	assert.Equal(t, types.ConfigDigest{1}, digest)
+
+	// config without snapshot which cannot be rebuilt from its proposal
+	reader.On("GetTransaction", mock.Anything, solana.Signature{4}, mock.Anything).Return(nil, rpc.ErrNotFound)
+	for block, msg := range map[uint64]string{
+		0: "no SetConfig event",
+		2: "does not match",
+		3: "no longer available",
+	} {
+		_, err = tracker.LatestConfig(ctx, block)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestConfigHistory_Eviction(t *testing.T) {
+	h := newConfigHistory()
+	for i := 0; i <= maxConfigHistory; i++ {
+		h.Add(uint64(i), types.ContractConfig{ConfigDigest: types.ConfigDigest{byte(i)}})
+	}
+	_, ok := h.Get(types.ConfigDigest{0})
+	assert.False(t, ok)
+	_, ok = h.Digest(0)
+	assert.False(t, ok)
+	cfg, ok := h.Get(types.ConfigDigest{maxConfigHistory})
+	assert.True(t, ok)
+	assert.Equal(t, types.ConfigDigest{maxConfigHistory}, cfg.ConfigDigest)
+}
@@ -2,7 +2,6 @@ package solana
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
@@ -13,12 +12,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/smartcontractkit/chainlink/core/utils"
 
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/audit"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
 )
 
 var (
+	// configVersion is the current state account version
 	configVersion uint8 = 1
 )
 
@@ -32,17 +34,23 @@ type ContractTracker struct {
 	// private key for the transmission signing
 	Transmitter TransmissionSigner
 
-	// tracked contract state
-	state  State
-	answer Answer
+	// polled state, shared with the trackers of other jobs watching the feed
+	*feedPoller
+
+	// cached transmitter balance (lamports)
+	balance uint64
 
 	// read/write mutexes
-	stateLock *sync.RWMutex
-	ansLock   *sync.RWMutex
+	balanceLock *sync.RWMutex
 
 	// stale state parameters
-	stateTime time.Time
-	ansTime   time.Time
+	balanceTime time.Time
+
+	// degraded conditions reported through Healthy
+	health *healthReport
+
+	// optional transmission audit log
+	auditLog *audit.Log
 
 	// dependencies
 	reader    client.Reader
@@ -50,156 +58,103 @@ type ContractTracker struct {
 	cfg       config.Config
 	lggr      logger.Logger
 
-	// polling
-	done   chan struct{}
-	ctx    context.Context
-	cancel context.CancelFunc
-
 	utils.StartStopOnce
 }
 
 func NewTracker(spec OCR2Spec, cfg config.Config, reader client.Reader, txManager TxManager, transmitter TransmissionSigner, lggr logger.Logger) ContractTracker {
+	return newTracker(spec, newFeedPoller(spec, cfg, reader, lggr), cfg, reader, txManager, transmitter, lggr)
+}
+
+// newTracker creates a tracker reading the state polled by poller
+func newTracker(spec OCR2Spec, poller *feedPoller, cfg config.Config, reader client.Reader, txManager TxManager, transmitter TransmissionSigner, lggr logger.Logger) ContractTracker {
 	return ContractTracker{
 		ProgramID:       spec.ProgramID,
 		StateID:         spec.StateID,
 		StoreProgramID:  spec.StoreProgramID,
 		TransmissionsID: spec.TransmissionsID,
 		Transmitter:     transmitter,
+		feedPoller:      poller,
 		reader:          reader,
 		txManager:       txManager,
 		lggr:            lggr,
 		cfg:             cfg,
-		stateLock:       &sync.RWMutex{},
-		ansLock:         &sync.RWMutex{},
+		balanceLock:     &sync.RWMutex{},
+		health:          newHealthReport(),
+		auditLog:        spec.AuditLog,
 	}
 }
 
-// Start polling
+// Start polling, the poller is started by the first tracker sharing it
 func (c *ContractTracker) Start() error {
 	return c.StartOnce("pollState", func() error {
-		c.done = make(chan struct{})
-		ctx, cancel := context.WithCancel(context.Background())
-		c.ctx = ctx
-		c.cancel = cancel
-		// We synchronously update the config on start so that
-		// when OCR starts there is config available (if possible).
-		// Avoids confusing "contract has not been configured" OCR errors.
-		err := c.fetchState(c.ctx)
-		if err != nil {
-			c.lggr.Warnf("error in initial PollState.fetchState %s", err)
-		}
-		go c.PollState()
+		c.feedPoller.subscribe(c)
 		return nil
 	})
 }
 
-// PollState contains the state and transmissions polling implementation
-func (c *ContractTracker) PollState() {
-	defer close(c.done)
-	c.lggr.Debugf("Starting state polling for state: %s, transmissions: %s", c.StateID, c.TransmissionsID)
-	tick := time.After(0)
-	for {
-		select {
-		case <-c.ctx.Done():
-			c.lggr.Debugf("Stopping state polling for state: %s, transmissions: %s", c.StateID, c.TransmissionsID)
-			return
-		case <-tick:
-			// async poll both transmission + ocr2 states
-			start := time.Now()
-			var wg sync.WaitGroup
-			wg.Add(2)
-			go func() {
-				defer wg.Done()
-				err := c.fetchState(c.ctx)
-				if err != nil {
-					c.lggr.Errorf("error in PollState.fetchState %s", err)
-				}
-			}()
-			go func() {
-				defer wg.Done()
-				err := c.fetchLatestTransmission(c.ctx)
-				if err != nil {
-					c.lggr.Errorf("error in PollState.fetchLatestTransmission %s", err)
-				}
-			}()
-			wg.Wait()
-
-			// Note negative duration will be immediately ready
-			tick = time.After(utils.WithJitter(c.cfg.OCR2CachePollPeriod()) - time.Since(start))
-		}
-	}
-}
-
-// Close stops the polling
+// Close stops the polling once no other tracker shares the poller
 func (c *ContractTracker) Close() error {
 	return c.StopOnce("pollState", func() error {
-		c.cancel()
-		<-c.done
+		c.feedPoller.unsubscribe(c)
 		return nil
 	})
 }
 
-// ReadState reads the latest state from memory with mutex and errors if timeout is exceeded
-func (c *ContractTracker) ReadState() (State, error) {
-	c.stateLock.RLock()
-	defer c.stateLock.RUnlock()
-
-	var err error
-	if time.Since(c.stateTime) > c.cfg.OCR2CacheTTL() {
-		err = errors.New("error in ReadState: stale state data, polling is likely experiencing errors")
+// Healthy returns an error if the tracker is not running or any degraded condition has been reported
+func (c *ContractTracker) Healthy() error {
+	if err := c.StartStopOnce.Healthy(); err != nil {
+		return err
 	}
-	return c.state, err
+	return c.health.Err()
 }
 
-// ReadAnswer reads the latest state from memory with mutex and errors if timeout is exceeded
-func (c *ContractTracker) ReadAnswer() (Answer, error) {
-	c.ansLock.RLock()
-	defer c.ansLock.RUnlock()
+// ReadBalance reads the cached transmitter balance, refreshing it from the chain once older than BalancePollPeriod
+func (c *ContractTracker) ReadBalance() (uint64, error) {
+	c.balanceLock.RLock()
+	balance, balanceTime := c.balance, c.balanceTime
+	c.balanceLock.RUnlock()
+	if time.Since(balanceTime) <= c.cfg.BalancePollPeriod() {
+		return balance, nil
+	}
 
-	// check if stale timeout
-	var err error
-	if time.Since(c.ansTime) > c.cfg.OCR2CacheTTL() {
-		err = errors.New("error in ReadAnswer: stale answer data, polling is likely experiencing errors")
+	balance, err := c.reader.Balance(c.Transmitter.PublicKey())
+	if err != nil {
+		return 0, errors.Wrap(err, "error in ReadBalance.Balance")
 	}
-	return c.answer, err
+
+	c.balanceLock.Lock()
+	defer c.balanceLock.Unlock()
+	c.balance = balance
+	c.balanceTime = time.Now()
+	return balance, nil
 }
 
-// fetch + decode + store raw state
+// fetchState polls the state and runs the checks of the job
 func (c *ContractTracker) fetchState(ctx context.Context) error {
-
-	c.lggr.Debugf("fetch state for account: %s", c.StateID.String())
-	state, _, err := GetState(ctx, c.reader, c.StateID, c.cfg.Commitment())
+	state, changed, err := c.feedPoller.fetchState(ctx)
 	if err != nil {
 		return err
 	}
-
-	c.lggr.Debugf("state fetched for account: %s, result (config digest): %v", c.StateID, hex.EncodeToString(state.Config.LatestConfigDigest[:]))
-
-	// acquire lock and write to state
-	c.stateLock.Lock()
-	defer c.stateLock.Unlock()
-	c.state = state
-	c.stateTime = time.Now()
+	c.onState(state, changed)
 	return nil
 }
 
-func (c *ContractTracker) fetchLatestTransmission(ctx context.Context) error {
-	c.lggr.Debugf("fetch latest transmission for account: %s", c.TransmissionsID)
-	answer, _, err := GetLatestTransmission(ctx, c.reader, c.TransmissionsID, c.cfg.Commitment())
-	if err != nil {
-		return err
+// onState checks a polled state against the job, changed reports a new config digest
+func (c *ContractTracker) onState(state State, changed bool) {
+	// bootstrap jobs have no transmitter, drift is logged by the check when it starts and ends
+	if c.Transmitter != nil {
+		_ = c.checkTransmitter(state)
 	}
-	c.lggr.Debugf("latest transmission fetched for account: %s, result: %v", c.TransmissionsID, answer)
 
-	// acquire lock and write to state
-	c.ansLock.Lock()
-	defer c.ansLock.Unlock()
-	c.answer = answer
-	c.ansTime = time.Now()
-	return nil
+	// sanity check offchain parameters once per config, reports of an unknown config may match the new one
+	if changed {
+		c.health.Set(healthKeyConfigDigest, nil)
+		c.checkOffchainConfig(state)
+	}
 }
 
-func GetState(ctx context.Context, reader client.AccountReader, account solana.PublicKey, commitment rpc.CommitmentType) (State, uint64, error) {
+// GetState fetches and decodes a state account, the account must be owned by programID
+func GetState(ctx context.Context, reader client.AccountReader, programID, account solana.PublicKey, commitment rpc.CommitmentType) (State, uint64, error) {
 	res, err := reader.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
 		Commitment: commitment,
 		Encoding:   "base64",
@@ -212,25 +167,88 @@ func GetState(ctx context.Context, reader client.AccountReader, account solana.P
 	if res == nil || res.Value == nil || res.Value.Data == nil {
 		return State{}, 0, errors.New("nil pointer returned in GetState.GetAccountInfoWithOpts")
 	}
-
-	var state State
-	if err := bin.NewBinDecoder(res.Value.Data.GetBinary()).Decode(&state); err != nil {
-		return State{}, 0, fmt.Errorf("failed to decode state account data: %w", err)
+	if err := checkOwner(account, res.Value, programID); err != nil {
+		return State{}, 0, err
 	}
 
-	// validation for config version
-	if configVersion != state.Version {
-		return State{}, 0, fmt.Errorf("decoded config version (%d) does not match expected config version (%d)", state.Version, configVersion)
+	state, err := DecodeState(res.Value.Data.GetBinary())
+	if err != nil {
+		return State{}, 0, withAccount(err, account)
 	}
 
 	blockNum := res.RPCContext.Context.Slot
 	return state, blockNum, nil
 }
 
-func GetLatestTransmission(ctx context.Context, reader client.AccountReader, account solana.PublicKey, commitment rpc.CommitmentType) (Answer, uint64, error) {
-	// query for transmission header
-	headerStart := AccountDiscriminatorLen // skip account discriminator
-	headerLen := TransmissionsHeaderLen
+// ErrStateLayout is returned by GetStateConfig for state account versions without a known config region
+var ErrStateLayout = errors.New("state account version does not support partial reads")
+
+// stateConfig is the beginning of a state account, up to and including the config
+type stateConfig struct {
+	AccountDiscriminator [8]byte
+	Version              uint8
+	Nonce                uint8
+	Padding0             uint16
+	Padding1             uint32
+	Transmissions        solana.PublicKey
+	Config               Config
+}
+
+// GetStateConfig fetches the header and config of a state account with a data slice.
+// The offchain config and oracles of the returned state are empty.
+func GetStateConfig(ctx context.Context, reader client.AccountReader, programID, account solana.PublicKey, commitment rpc.CommitmentType) (State, uint64, error) {
+	offset, length := uint64(0), StateHeaderLen+ConfigLen
+	res, err := reader.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Commitment: commitment,
+		Encoding:   "base64",
+		DataSlice: &rpc.DataSlice{
+			Offset: &offset,
+			Length: &length,
+		},
+	})
+	if err != nil {
+		return State{}, 0, fmt.Errorf("failed to fetch state account config at address '%s': %w", account.String(), err)
+	}
+
+	// check for nil pointers
+	if res == nil || res.Value == nil || res.Value.Data == nil {
+		return State{}, 0, errors.New("nil pointer returned in GetStateConfig.GetAccountInfoWithOpts")
+	}
+	if err := checkOwner(account, res.Value, programID); err != nil {
+		return State{}, 0, err
+	}
+
+	data := res.Value.Data.GetBinary()
+	if err := checkDiscriminator(data, "State", ocr_2.StateDiscriminator); err != nil {
+		return State{}, 0, withAccount(err, account)
+	}
+	if uint64(len(data)) < length {
+		return State{}, 0, fmt.Errorf("state account config too short: %d bytes", len(data))
+	}
+	if version := data[AccountDiscriminatorLen]; version != configVersion {
+		return State{}, 0, fmt.Errorf("%w: version %d", ErrStateLayout, version)
+	}
+	var partial stateConfig
+	if err := bin.NewBinDecoder(data).Decode(&partial); err != nil {
+		return State{}, 0, fmt.Errorf("failed to decode state account config: %w", err)
+	}
+	return State{
+		AccountDiscriminator: partial.AccountDiscriminator,
+		Version:              partial.Version,
+		Nonce:                partial.Nonce,
+		Padding0:             partial.Padding0,
+		Padding1:             partial.Padding1,
+		Transmissions:        partial.Transmissions,
+		Config:               partial.Config,
+	}, res.RPCContext.Context.Slot, nil
+}
+
+// GetLatestTransmission fetches the latest transmission of a transmissions account, the account must be owned by storeProgramID.
+// It reads the header and the transmission with two requests, Feed.LatestTransmission usually needs a single one.
+func GetLatestTransmission(ctx context.Context, reader client.AccountReader, storeProgramID, account solana.PublicKey, commitment rpc.CommitmentType) (Answer, uint64, error) {
+	// query for transmission header, including the account discriminator
+	headerStart := uint64(0)
+	headerLen := AccountDiscriminatorLen + TransmissionsHeaderLen
 	res, err := reader.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
 		Encoding:   "base64",
 		Commitment: commitment,
@@ -247,15 +265,14 @@ func GetLatestTransmission(ctx context.Context, reader client.AccountReader, acc
 	if res == nil || res.Value == nil || res.Value.Data == nil {
 		return Answer{}, 0, errors.New("nil pointer returned in GetLatestTransmission.GetAccountInfoWithOpts.Header")
 	}
-
-	// parse header
-	var header TransmissionsHeader
-	if err = bin.NewBinDecoder(res.Value.Data.GetBinary()).Decode(&header); err != nil {
-		return Answer{}, 0, errors.Wrap(err, "failed to decode transmission account header")
+	if err := checkOwner(account, res.Value, storeProgramID); err != nil {
+		return Answer{}, 0, err
 	}
 
-	if header.Version != 2 {
-		return Answer{}, 0, errors.Wrapf(err, "can't parse feed version %v", header.Version)
+	// parse header
+	header, layout, err := decodeTransmissionsHeader(res.Value.Data.GetBinary())
+	if err != nil {
+		return Answer{}, 0, withAccount(err, account)
 	}
 
 	cursor := header.LiveCursor
@@ -267,7 +284,7 @@ func GetLatestTransmission(ctx context.Context, reader client.AccountReader, acc
 	cursor-- // cursor indicates index for new answer, latest answer is in previous index
 
 	// setup transmissionLen
-	transmissionLen := TransmissionLen
+	transmissionLen := layout.TransmissionLen
 
 	transmissionOffset := AccountDiscriminatorLen + TransmissionsHeaderMaxSize + (uint64(cursor) * transmissionLen)
 
@@ -288,9 +305,9 @@ func GetLatestTransmission(ctx context.Context, reader client.AccountReader, acc
 	}
 
 	// parse tranmission
-	var t Transmission
-	if err := bin.NewBinDecoder(res.Value.Data.GetBinary()).Decode(&t); err != nil {
-		return Answer{}, 0, errors.Wrap(err, "failed to decode transmission")
+	t, err := layout.DecodeTransmission(res.Value.Data.GetBinary())
+	if err != nil {
+		return Answer{}, 0, err
 	}
 
 	return Answer{
@@ -10,17 +10,19 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
-	"sync"
 	"testing"
 	"time"
 
+	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
 	"github.com/smartcontractkit/chainlink/core/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -107,7 +109,7 @@ func TestGetState(t *testing.T) {
 	defer mockServer.Close()
 
 	// happy path does not error (actual state decoding handled in types_test)
-	_, _, err := GetState(context.TODO(), testSetupReader(t, mockServer.URL), solana.PublicKey{}, "")
+	_, _, err := GetState(context.TODO(), testSetupReader(t, mockServer.URL), solana.PublicKey{}, solana.PublicKey{}, "")
 	require.NoError(t, err)
 }
 
@@ -133,17 +135,17 @@ func TestGetLatestTransmission(t *testing.T) {
 	defer mockServer.Close()
 
 	reader := testSetupReader(t, mockServer.URL)
-	a, _, err := GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, "")
+	a, _, err := GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedTime, a.Timestamp)
 	assert.Equal(t, expectedAns, a.Data.String())
 
 	// fail if returned transmission header is too short
-	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, "")
+	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.Error(t, err)
 
 	// fail if returned transmission is too short
-	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, "")
+	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.Error(t, err)
 }
 
@@ -171,15 +173,10 @@ func TestStatePolling(t *testing.T) {
 	}))
 
 	lggr := logger.TestLogger(t)
-	tracker := ContractTracker{
+	tracker := NewTracker(OCR2Spec{
 		StateID:         solana.MustPublicKeyFromBase58("11111111111111111111111111111111"),
 		TransmissionsID: solana.MustPublicKeyFromBase58("11111111111111111111111111111112"),
-		cfg:             config.NewConfig(db.ChainCfg{}, lggr),
-		reader:          testSetupReader(t, mockServer.URL),
-		lggr:            lggr,
-		stateLock:       &sync.RWMutex{},
-		ansLock:         &sync.RWMutex{},
-	}
+	}, config.NewConfig(db.ChainCfg{}, lggr), testSetupReader(t, mockServer.URL), nil, nil, lggr)
 	require.NoError(t, tracker.Start())
 	require.Error(t, tracker.Start()) // test startOnce
 	time.Sleep(wait)
@@ -217,15 +214,71 @@ func TestNilPointerHandling(t *testing.T) {
 	reader := testSetupReader(t, mockServer.URL)
 
 	// fail on get state query
-	_, _, err := GetState(context.TODO(), reader, solana.PublicKey{}, "")
+	_, _, err := GetState(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.EqualError(t, err, errString+"GetState.GetAccountInfoWithOpts")
 
 	// fail on transmissions header query
-	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, "")
+	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.EqualError(t, err, errString+"GetLatestTransmission.GetAccountInfoWithOpts.Header")
 
 	passFirst = true // allow proper response for header query, fail on transmission
-	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, "")
+	_, _, err = GetLatestTransmission(context.TODO(), reader, solana.PublicKey{}, solana.PublicKey{}, "")
 	assert.EqualError(t, err, errString+"GetLatestTransmission.GetAccountInfoWithOpts.Transmission")
 
 }
+
+func TestFetchState_DataSlices(t *testing.T) {
+	_, state := testOracleIdentities(t, 4)
+	state.Config.LatestConfigDigest = [32]byte{1}
+	state.Config.ConfigCount = 1
+	lggr := logger.TestLogger(t)
+	reader := new(mocks.ReaderWriter)
+	tracker := NewTracker(OCR2Spec{StateID: solana.NewWallet().PublicKey()}, config.NewConfig(db.ChainCfg{}, lggr), reader, nil, nil, lggr)
+	expectReads := func(full bool) {
+		var buf bytes.Buffer
+		require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+		data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()[:StateHeaderLen+ConfigLen]))
+		require.NoError(t, err)
+		reader.On("GetAccountInfoWithOpts", mock.Anything, tracker.StateID, mock.MatchedBy(func(opts *rpc.GetAccountInfoOpts) bool {
+			return opts.DataSlice != nil && *opts.DataSlice.Offset == 0 && *opts.DataSlice.Length == StateHeaderLen+ConfigLen
+		})).Return(&rpc.GetAccountInfoResult{Value: &rpc.Account{Data: data}}, nil).Once()
+		if full {
+			reader.On("GetAccountInfoWithOpts", mock.Anything, tracker.StateID, mock.MatchedBy(func(opts *rpc.GetAccountInfoOpts) bool {
+				return opts.DataSlice == nil
+			})).Return(testStateAccount(t, state), nil).Once()
+		}
+	}
+	ctx := context.Background()
+
+	// the config region decodes like the full account
+	expectReads(false)
+	partial, _, err := GetStateConfig(ctx, reader, solana.PublicKey{}, tracker.StateID, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, state.Config, partial.Config)
+	assert.Zero(t, partial.Oracles.Len)
+
+	// first poll reads the whole account
+	expectReads(true)
+	require.NoError(t, tracker.fetchState(ctx))
+	assert.Equal(t, state, tracker.state)
+
+	// unchanged config keeps the cached oracles and offchain config
+	state.Config.Epoch, state.Config.Round = 2, 3
+	expectReads(false)
+	require.NoError(t, tracker.fetchState(ctx))
+	assert.Equal(t, state, tracker.state)
+
+	// a new config count triggers a full read
+	state.Config.ConfigCount = 2
+	state.Oracles.Len = 3
+	expectReads(true)
+	require.NoError(t, tracker.fetchState(ctx))
+	assert.Equal(t, state, tracker.state)
+	reader.AssertExpectations(t)
+
+	// other state layouts are read in full
+	state.Version = 200
+	expectReads(false)
+	_, _, err = GetStateConfig(ctx, reader, solana.PublicKey{}, tracker.StateID, rpc.CommitmentConfirmed)
+	assert.ErrorIs(t, err, ErrStateLayout)
+}
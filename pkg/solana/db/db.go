@@ -53,13 +53,24 @@ type Node struct {
 }
 
 type ChainCfg struct {
-	BalancePollPeriod   *models.Duration
-	ConfirmPollPeriod   *models.Duration
-	OCR2CachePollPeriod *models.Duration
-	OCR2CacheTTL        *models.Duration
-	TxTimeout           *models.Duration
-	SkipPreflight       null.Bool // to enable or disable preflight checks
-	Commitment          null.String
+	BalancePollPeriod        *models.Duration
+	ConfirmPollPeriod        *models.Duration
+	OCR2CachePollPeriod      *models.Duration
+	OCR2CacheTTL             *models.Duration
+	TxTimeout                *models.Duration
+	SkipPreflight            null.Bool // to enable or disable preflight checks
+	Commitment               null.String
+	MinTransmitterBalance    null.Int // lamports
+	BalanceWarnThreshold     null.Int // lamports
+	BalanceCriticalThreshold null.Int // lamports
+	VerifyReportSignatures   null.Bool
+
+	OffchainConfigMinDeltaRound    *models.Duration
+	OffchainConfigMaxDeltaRound    *models.Duration
+	OffchainConfigMinDeltaProgress *models.Duration
+	OffchainConfigMaxDeltaProgress *models.Duration
+	OffchainConfigMaxDuration      *models.Duration
+	OffchainConfigMaxAlphaPPB      null.Int
 }
 
 func (c *ChainCfg) Scan(value interface{}) error {
@@ -0,0 +1,147 @@
+package solana
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/pkg/errors"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/contracts/generated/store"
+)
+
+// TransmissionLenV1 = Timestamp, Answer
+const TransmissionLenV1 uint64 = 8 + 16
+
+// StateDecoder decodes the raw data of an OCR2 state account layout into the current State
+type StateDecoder func(data []byte) (State, error)
+
+// FeedLayout describes a store program transmissions account layout, entries are normalized to the current types
+type FeedLayout struct {
+	DecodeHeader       func(data []byte) (TransmissionsHeader, error)
+	TransmissionLen    uint64
+	DecodeTransmission func(data []byte) (Transmission, error)
+}
+
+var (
+	decodersLock  sync.RWMutex
+	stateDecoders = map[uint8]StateDecoder{
+		configVersion: decodeStateV1,
+	}
+	feedLayouts = map[uint8]FeedLayout{
+		1:           {DecodeHeader: decodeTransmissionsHeaderV1, TransmissionLen: TransmissionLenV1, DecodeTransmission: decodeTransmissionV1},
+		FeedVersion: {DecodeHeader: decodeTransmissionsHeaderV1, TransmissionLen: TransmissionLen, DecodeTransmission: decodeTransmissionV2},
+	}
+)
+
+// RegisterStateDecoder adds or replaces the decoder of a state account version
+func RegisterStateDecoder(version uint8, decode StateDecoder) {
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+	stateDecoders[version] = decode
+}
+
+// RegisterFeedLayout adds or replaces the layout of a transmissions account version
+func RegisterFeedLayout(version uint8, layout FeedLayout) {
+	decodersLock.Lock()
+	defer decodersLock.Unlock()
+	feedLayouts[version] = layout
+}
+
+// DecodeState decodes a state account with the decoder registered for its version
+func DecodeState(data []byte) (State, error) {
+	if err := checkDiscriminator(data, "State", ocr_2.StateDiscriminator); err != nil {
+		return State{}, err
+	}
+	// version follows the account discriminator
+	if uint64(len(data)) <= AccountDiscriminatorLen {
+		return State{}, fmt.Errorf("state account too short: %d bytes", len(data))
+	}
+	version := data[AccountDiscriminatorLen]
+	decodersLock.RLock()
+	decode, ok := stateDecoders[version]
+	decodersLock.RUnlock()
+	if !ok {
+		return State{}, fmt.Errorf("unsupported state account version %d (supported: %v)", version, stateVersions())
+	}
+	return decode(data)
+}
+
+// feedLayout returns the layout registered for a transmissions account version
+func feedLayout(version uint8) (FeedLayout, error) {
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+	layout, ok := feedLayouts[version]
+	if !ok {
+		return FeedLayout{}, fmt.Errorf("can't parse feed version %v", version)
+	}
+	return layout, nil
+}
+
+// decodeTransmissionsHeader decodes a transmissions account header, data starts with the account discriminator
+func decodeTransmissionsHeader(data []byte) (TransmissionsHeader, FeedLayout, error) {
+	if err := checkDiscriminator(data, "Transmissions", store.TransmissionsDiscriminator); err != nil {
+		return TransmissionsHeader{}, FeedLayout{}, err
+	}
+	data = data[AccountDiscriminatorLen:]
+	if len(data) == 0 {
+		return TransmissionsHeader{}, FeedLayout{}, errors.New("failed to decode transmission account header: no data")
+	}
+	layout, err := feedLayout(data[0])
+	if err != nil {
+		return TransmissionsHeader{}, FeedLayout{}, err
+	}
+	header, err := layout.DecodeHeader(data)
+	if err != nil {
+		return TransmissionsHeader{}, FeedLayout{}, err
+	}
+	return header, layout, nil
+}
+
+func stateVersions() []uint8 {
+	decodersLock.RLock()
+	defer decodersLock.RUnlock()
+	var versions []uint8
+	for v := range stateDecoders {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func decodeStateV1(data []byte) (State, error) {
+	var state State
+	if err := bin.NewBinDecoder(data).Decode(&state); err != nil {
+		return State{}, fmt.Errorf("failed to decode state account data: %w", err)
+	}
+	return state, nil
+}
+
+func decodeTransmissionsHeaderV1(data []byte) (TransmissionsHeader, error) {
+	var header TransmissionsHeader
+	if err := bin.NewBinDecoder(data).Decode(&header); err != nil {
+		return TransmissionsHeader{}, errors.Wrap(err, "failed to decode transmission account header")
+	}
+	return header, nil
+}
+
+func decodeTransmissionV1(data []byte) (Transmission, error) {
+	var t TransmissionV1
+	if err := bin.NewBinDecoder(data).Decode(&t); err != nil {
+		return Transmission{}, errors.Wrap(err, "failed to decode transmission")
+	}
+	return Transmission{
+		Timestamp: uint32(t.Timestamp),
+		Answer:    t.Answer,
+	}, nil
+}
+
+func decodeTransmissionV2(data []byte) (Transmission, error) {
+	var t Transmission
+	if err := bin.NewBinDecoder(data).Decode(&t); err != nil {
+		return Transmission{}, errors.Wrap(err, "failed to decode transmission")
+	}
+	return t, nil
+}
@@ -0,0 +1,96 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+)
+
+func TestDecodeState(t *testing.T) {
+	state := State{AccountDiscriminator: ocr_2.StateDiscriminator, Version: configVersion}
+	state.Config.F = 1
+	var buf bytes.Buffer
+	require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+
+	decoded, err := DecodeState(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), decoded.Config.F)
+
+	// unknown version
+	data := buf.Bytes()
+	data[AccountDiscriminatorLen] = 200
+	_, err = DecodeState(data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported state account version 200")
+
+	// future layouts are registered
+	RegisterStateDecoder(200, func(data []byte) (State, error) {
+		return State{Version: data[AccountDiscriminatorLen]}, nil
+	})
+	t.Cleanup(func() {
+		decodersLock.Lock()
+		defer decodersLock.Unlock()
+		delete(stateDecoders, 200)
+	})
+	decoded, err = DecodeState(data)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(200), decoded.Version)
+
+	_, err = DecodeState(data[:AccountDiscriminatorLen])
+	assert.Error(t, err)
+}
+
+func TestFeedV1(t *testing.T) {
+	// feeds not migrated yet store transmissions without slot
+	store := newTestStore(t, 4, 3, 2)
+	store.header.Version = 1
+	store.insert(9)
+	account := solana.NewWallet().PublicKey()
+
+	answer, _, err := GetLatestTransmission(context.Background(), store, store.program, account, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(9), answer.Timestamp)
+	assert.Equal(t, int64(9), answer.Data.Int64())
+
+	rounds, err := GetRounds(context.Background(), NewFeed(store, store.program, account, rpc.CommitmentConfirmed), 1, 9)
+	require.NoError(t, err)
+	var ids []uint32
+	for _, r := range rounds {
+		ids = append(ids, r.RoundID)
+		assert.Equal(t, r.RoundID, r.Timestamp)
+		assert.Zero(t, r.Slot)
+	}
+	assert.Equal(t, []uint32{4, 6, 7, 8, 9}, ids)
+
+	header, _, err := GetFeedHeader(context.Background(), store, store.program, account, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), header.Version)
+}
+
+func TestRegisterFeedLayout(t *testing.T) {
+	store := newTestStore(t, 4, 3, 2)
+	store.insert(3)
+	store.header.Version = 3
+	account := solana.NewWallet().PublicKey()
+	_, _, err := GetLatestTransmission(context.Background(), store, store.program, account, rpc.CommitmentConfirmed)
+	require.Error(t, err)
+
+	// same layout as the current version under a new version number
+	RegisterFeedLayout(3, feedLayouts[FeedVersion])
+	t.Cleanup(func() {
+		decodersLock.Lock()
+		defer decodersLock.Unlock()
+		delete(feedLayouts, 3)
+	})
+	answer, _, err := GetLatestTransmission(context.Background(), store, store.program, account, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), answer.Timestamp)
+}
@@ -0,0 +1,317 @@
+// Package drift compares a desired OCR2 feed config with the config stored in the on-chain state account.
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+// Duration is a time.Duration encoded as a string, e.g. "1m30s"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Oracle is a desired oracle, Signer is hex encoded
+type Oracle struct {
+	Signer      string           `json:"signer"`
+	Transmitter solana.PublicKey `json:"transmitter"`
+	Payee       solana.PublicKey `json:"payee"`
+}
+
+// Billing is the desired billing config in gjuels
+type Billing struct {
+	ObservationPayment  uint32 `json:"observationPayment"`
+	TransmissionPayment uint32 `json:"transmissionPayment"`
+}
+
+// OffchainConfig holds the desired libocr and median plugin offchain parameters
+type OffchainConfig struct {
+	Version *uint64 `json:"version,omitempty"`
+
+	DeltaProgress *Duration `json:"deltaProgress,omitempty"`
+	DeltaResend   *Duration `json:"deltaResend,omitempty"`
+	DeltaRound    *Duration `json:"deltaRound,omitempty"`
+	DeltaGrace    *Duration `json:"deltaGrace,omitempty"`
+	DeltaStage    *Duration `json:"deltaStage,omitempty"`
+	RMax          *uint8    `json:"rMax,omitempty"`
+	S             []int     `json:"s,omitempty"`
+
+	MaxDurationQuery                        *Duration `json:"maxDurationQuery,omitempty"`
+	MaxDurationObservation                  *Duration `json:"maxDurationObservation,omitempty"`
+	MaxDurationReport                       *Duration `json:"maxDurationReport,omitempty"`
+	MaxDurationShouldAcceptFinalizedReport  *Duration `json:"maxDurationShouldAcceptFinalizedReport,omitempty"`
+	MaxDurationShouldTransmitAcceptedReport *Duration `json:"maxDurationShouldTransmitAcceptedReport,omitempty"`
+
+	// median reporting plugin config
+	AlphaReportInfinite *bool     `json:"alphaReportInfinite,omitempty"`
+	AlphaReportPPB      *uint64   `json:"alphaReportPPB,omitempty"`
+	AlphaAcceptInfinite *bool     `json:"alphaAcceptInfinite,omitempty"`
+	AlphaAcceptPPB      *uint64   `json:"alphaAcceptPPB,omitempty"`
+	DeltaC              *Duration `json:"deltaC,omitempty"`
+}
+
+// Desired is a desired feed config document, omitted fields are not compared
+type Desired struct {
+	Oracles                   []Oracle          `json:"oracles,omitempty"`
+	F                         *uint8            `json:"f,omitempty"`
+	MinAnswer                 *string           `json:"minAnswer,omitempty"`
+	MaxAnswer                 *string           `json:"maxAnswer,omitempty"`
+	TokenMint                 *solana.PublicKey `json:"tokenMint,omitempty"`
+	Billing                   *Billing          `json:"billing,omitempty"`
+	RequesterAccessController *solana.PublicKey `json:"requesterAccessController,omitempty"`
+	BillingAccessController   *solana.PublicKey `json:"billingAccessController,omitempty"`
+	OffchainConfig            *OffchainConfig   `json:"offchainConfig,omitempty"`
+}
+
+// ParseDesired decodes a desired config document, unknown fields are rejected to catch typos
+func ParseDesired(r io.Reader) (Desired, error) {
+	var d Desired
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&d); err != nil {
+		return Desired{}, fmt.Errorf("failed to decode desired config: %w", err)
+	}
+	for _, v := range []*string{d.MinAnswer, d.MaxAnswer} {
+		if v == nil {
+			continue
+		}
+		if _, ok := new(big.Int).SetString(*v, 10); !ok {
+			return Desired{}, fmt.Errorf("invalid answer bound %q", *v)
+		}
+	}
+	signers := map[[20]byte]bool{}
+	for _, o := range d.Oracles {
+		signer, err := parseSigner(o.Signer)
+		if err != nil {
+			return Desired{}, err
+		}
+		if signers[signer] {
+			return Desired{}, fmt.Errorf("duplicate signer %x", signer)
+		}
+		signers[signer] = true
+	}
+	return d, nil
+}
+
+func parseSigner(s string) ([20]byte, error) {
+	var signer [20]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(signer) {
+		return signer, fmt.Errorf("invalid signer %q: expected 20 hex encoded bytes", s)
+	}
+	copy(signer[:], b)
+	return signer, nil
+}
+
+// Difference is a single field where the on-chain config differs from the desired config
+type Difference struct {
+	Field   string `json:"field"`
+	Desired string `json:"desired"`
+	Actual  string `json:"actual"`
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: desired %s, actual %s", d.Field, d.Desired, d.Actual)
+}
+
+// Diff lists all differences, it is empty if the on-chain config matches
+type Diff []Difference
+
+func (d *Diff) add(field string, desired, actual interface{}) {
+	desiredStr, actualStr := fmt.Sprint(desired), fmt.Sprint(actual)
+	if desiredStr != actualStr {
+		*d = append(*d, Difference{Field: field, Desired: desiredStr, Actual: actualStr})
+	}
+}
+
+// addAnswer compares an answer bound numerically, so that equal values written differently such as "+100" do not differ
+func (d *Diff) addAnswer(field string, desired string, actual *big.Int) error {
+	v, ok := new(big.Int).SetString(desired, 10)
+	if !ok {
+		return fmt.Errorf("invalid answer bound %q", desired)
+	}
+	if v.Cmp(actual) != 0 {
+		*d = append(*d, Difference{Field: field, Desired: v.String(), Actual: actual.String()})
+	}
+	return nil
+}
+
+// Check fetches the state account owned by programID and compares it with desired
+func Check(ctx context.Context, reader client.AccountReader, programID, stateID solana.PublicKey, desired Desired, commitment rpc.CommitmentType) (Diff, error) {
+	state, _, err := relaySol.GetState(ctx, reader, programID, stateID, commitment)
+	if err != nil {
+		return nil, errors.Wrap(err, "error in Check.GetState")
+	}
+	return Compare(desired, state)
+}
+
+// Compare returns the differences between desired and state
+func Compare(desired Desired, state relaySol.State) (Diff, error) {
+	var diff Diff
+	cfg := state.Config
+
+	if desired.Oracles != nil {
+		if err := compareOracles(&diff, desired.Oracles, state.Oracles); err != nil {
+			return nil, err
+		}
+	}
+	if desired.F != nil {
+		diff.add("f", *desired.F, cfg.F)
+	}
+	if desired.MinAnswer != nil {
+		if err := diff.addAnswer("minAnswer", *desired.MinAnswer, cfg.MinAnswer.BigInt()); err != nil {
+			return nil, err
+		}
+	}
+	if desired.MaxAnswer != nil {
+		if err := diff.addAnswer("maxAnswer", *desired.MaxAnswer, cfg.MaxAnswer.BigInt()); err != nil {
+			return nil, err
+		}
+	}
+	if desired.TokenMint != nil {
+		diff.add("tokenMint", *desired.TokenMint, cfg.TokenMint)
+	}
+	if desired.Billing != nil {
+		diff.add("billing.observationPayment", desired.Billing.ObservationPayment, cfg.Billing.ObservationPayment)
+		diff.add("billing.transmissionPayment", desired.Billing.TransmissionPayment, cfg.Billing.TransmissionPayment)
+	}
+	if desired.RequesterAccessController != nil {
+		diff.add("requesterAccessController", *desired.RequesterAccessController, cfg.RequesterAccessController)
+	}
+	if desired.BillingAccessController != nil {
+		diff.add("billingAccessController", *desired.BillingAccessController, cfg.BillingAccessController)
+	}
+	if desired.OffchainConfig != nil {
+		compareOffchainConfig(&diff, *desired.OffchainConfig, state)
+	}
+	return diff, nil
+}
+
+// compareOracles matches oracles by signer, the program stores them sorted by signer
+func compareOracles(diff *Diff, desired []Oracle, actual relaySol.Oracles) error {
+	oracles, err := actual.Data()
+	if err != nil {
+		return err
+	}
+	diff.add("oracles.len", len(desired), len(oracles))
+
+	onchain := map[[20]byte]relaySol.Oracle{}
+	for _, o := range oracles {
+		onchain[o.Signer.Key] = o
+	}
+	wanted := map[[20]byte]bool{}
+	for _, o := range desired {
+		signer, err := parseSigner(o.Signer)
+		if err != nil {
+			return err
+		}
+		if wanted[signer] {
+			return fmt.Errorf("duplicate signer %x", signer)
+		}
+		wanted[signer] = true
+		field := fmt.Sprintf("oracles[%x]", signer)
+		current, ok := onchain[signer]
+		if !ok {
+			diff.add(field+".signer", hex.EncodeToString(signer[:]), "missing")
+			continue
+		}
+		diff.add(field+".transmitter", o.Transmitter, current.Transmitter)
+		if !o.Payee.IsZero() {
+			diff.add(field+".payee", o.Payee, current.Payee)
+		}
+	}
+	var unexpected [][20]byte
+	for signer := range onchain {
+		if !wanted[signer] {
+			unexpected = append(unexpected, signer)
+		}
+	}
+	sort.Slice(unexpected, func(i, j int) bool { return bytes.Compare(unexpected[i][:], unexpected[j][:]) < 0 })
+	for _, signer := range unexpected {
+		diff.add(fmt.Sprintf("oracles[%x].signer", signer), "missing", hex.EncodeToString(signer[:]))
+	}
+	return nil
+}
+
+func compareOffchainConfig(diff *Diff, desired OffchainConfig, state relaySol.State) {
+	if desired.Version != nil {
+		diff.add("offchainConfig.version", *desired.Version, state.OffchainConfig.Version)
+	}
+
+	params, err := relaySol.DecodeStateOffchainConfig(state)
+	if err != nil {
+		diff.add("offchainConfig", "decodable", fmt.Sprintf("undecodable (%s)", err))
+		return
+	}
+
+	durations := []struct {
+		field   string
+		desired *Duration
+		actual  time.Duration
+	}{
+		{"deltaProgress", desired.DeltaProgress, params.DeltaProgress},
+		{"deltaResend", desired.DeltaResend, params.DeltaResend},
+		{"deltaRound", desired.DeltaRound, params.DeltaRound},
+		{"deltaGrace", desired.DeltaGrace, params.DeltaGrace},
+		{"deltaStage", desired.DeltaStage, params.DeltaStage},
+		{"maxDurationQuery", desired.MaxDurationQuery, params.MaxDurationQuery},
+		{"maxDurationObservation", desired.MaxDurationObservation, params.MaxDurationObservation},
+		{"maxDurationReport", desired.MaxDurationReport, params.MaxDurationReport},
+		{"maxDurationShouldAcceptFinalizedReport", desired.MaxDurationShouldAcceptFinalizedReport, params.MaxDurationShouldAcceptFinalizedReport},
+		{"maxDurationShouldTransmitAcceptedReport", desired.MaxDurationShouldTransmitAcceptedReport, params.MaxDurationShouldTransmitAcceptedReport},
+		{"deltaC", desired.DeltaC, params.Median.DeltaC},
+	}
+	for _, d := range durations {
+		if d.desired != nil {
+			diff.add("offchainConfig."+d.field, time.Duration(*d.desired), d.actual)
+		}
+	}
+	if desired.RMax != nil {
+		diff.add("offchainConfig.rMax", *desired.RMax, params.RMax)
+	}
+	if desired.S != nil {
+		diff.add("offchainConfig.s", desired.S, params.S)
+	}
+	if desired.AlphaReportInfinite != nil {
+		diff.add("offchainConfig.alphaReportInfinite", *desired.AlphaReportInfinite, params.Median.AlphaReportInfinite)
+	}
+	if desired.AlphaReportPPB != nil {
+		diff.add("offchainConfig.alphaReportPPB", *desired.AlphaReportPPB, params.Median.AlphaReportPPB)
+	}
+	if desired.AlphaAcceptInfinite != nil {
+		diff.add("offchainConfig.alphaAcceptInfinite", *desired.AlphaAcceptInfinite, params.Median.AlphaAcceptInfinite)
+	}
+	if desired.AlphaAcceptPPB != nil {
+		diff.add("offchainConfig.alphaAcceptPPB", *desired.AlphaAcceptPPB, params.Median.AlphaAcceptPPB)
+	}
+}
@@ -0,0 +1,231 @@
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/libocr/offchainreporting2/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+)
+
+// testState returns a state with 4 oracles and an offchain config generated by libocr
+func testState(t *testing.T) relaySol.State {
+	var state relaySol.State
+	state.AccountDiscriminator = ocr_2.StateDiscriminator
+	state.Version = 1
+	state.Config.F = 1
+	state.Config.MinAnswer = bin.Int128{Lo: 1}
+	state.Config.MaxAnswer = bin.Int128{Lo: 1000}
+	state.Config.Billing = relaySol.Billing{ObservationPayment: 1, TransmissionPayment: 2}
+	state.Config.RequesterAccessController = solana.PublicKey{1}
+	state.Config.BillingAccessController = solana.PublicKey{2}
+
+	var identities []confighelper.OracleIdentityExtra
+	for i := 0; i < 4; i++ {
+		o := relaySol.Oracle{
+			Transmitter: solana.PublicKey{byte(10 + i)},
+			Payee:       solana.PublicKey{byte(20 + i)},
+		}
+		o.Signer.Key[0] = byte(i + 1)
+		configKey, err := curve25519.X25519(bytes.Repeat([]byte{byte(i + 1)}, curve25519.ScalarSize), curve25519.Basepoint)
+		require.NoError(t, err)
+		state.Oracles.Raw[i] = o
+		identity := confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OffchainPublicKey: types.OffchainPublicKey{byte(i + 1)},
+				OnchainPublicKey:  o.Signer.Key[:],
+				PeerID:            fmt.Sprintf("peer%d", i),
+				TransmitAccount:   types.Account(o.Transmitter.String()),
+			},
+		}
+		copy(identity.ConfigEncryptionPublicKey[:], configKey)
+		identities = append(identities, identity)
+	}
+	state.Oracles.Len = 4
+
+	_, _, _, _, version, offchainConfig, err := confighelper.ContractSetConfigArgsForTests(
+		2*time.Second, 5*time.Second, time.Second, 500*time.Millisecond, 5*time.Second,
+		3, []int{1, 1, 1, 1}, identities,
+		median.OffchainConfig{AlphaReportPPB: 1000, AlphaAcceptPPB: 1000, DeltaC: time.Minute}.Encode(),
+		0, 100*time.Millisecond, 100*time.Millisecond, 100*time.Millisecond, 100*time.Millisecond,
+		1, nil,
+	)
+	require.NoError(t, err)
+	state.OffchainConfig.Version = version
+	state.OffchainConfig.Len = uint64(copy(state.OffchainConfig.Raw[:], offchainConfig))
+	return state
+}
+
+const testDesired = `{
+	"oracles": [
+		{"signer": "0x0100000000000000000000000000000000000000", "transmitter": "%s", "payee": "%s"},
+		{"signer": "0200000000000000000000000000000000000000", "transmitter": "%s"},
+		{"signer": "0300000000000000000000000000000000000000", "transmitter": "%s"},
+		{"signer": "0400000000000000000000000000000000000000", "transmitter": "%s"}
+	],
+	"f": 1,
+	"minAnswer": "1",
+	"maxAnswer": "1000",
+	"billing": {"observationPayment": 1, "transmissionPayment": 2},
+	"requesterAccessController": "%s",
+	"billingAccessController": "%s",
+	"offchainConfig": {
+		"version": 2,
+		"deltaProgress": "2s",
+		"deltaRound": "1s",
+		"rMax": 3,
+		"s": [1, 1, 1, 1],
+		"maxDurationObservation": "100ms",
+		"alphaReportPPB": 1000,
+		"alphaAcceptInfinite": false,
+		"deltaC": "1m"
+	}
+}`
+
+func testDesiredDoc(t *testing.T, state relaySol.State) Desired {
+	o := state.Oracles.Raw
+	doc := fmt.Sprintf(testDesired,
+		o[0].Transmitter, o[0].Payee, o[1].Transmitter, o[2].Transmitter, o[3].Transmitter,
+		state.Config.RequesterAccessController, state.Config.BillingAccessController,
+	)
+	desired, err := ParseDesired(strings.NewReader(doc))
+	require.NoError(t, err)
+	return desired
+}
+
+func TestParseDesired(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown field":  `{"fee": 1}`,
+		"invalid signer": `{"oracles": [{"signer": "01"}]}`,
+		"invalid answer": `{"minAnswer": "1.5"}`,
+		"duplicate signer": `{"oracles": [
+			{"signer": "0100000000000000000000000000000000000000", "transmitter": "11111111111111111111111111111111"},
+			{"signer": "0x0100000000000000000000000000000000000000", "transmitter": "11111111111111111111111111111111"}
+		]}`,
+		"invalid delta": `{"offchainConfig": {"deltaRound": "1 second"}}`,
+	} {
+		_, err := ParseDesired(strings.NewReader(doc))
+		assert.Error(t, err, name)
+	}
+
+	desired, err := ParseDesired(strings.NewReader(`{"f": 2, "offchainConfig": {"deltaRound": "1m30s"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), *desired.F)
+	assert.Equal(t, Duration(90*time.Second), *desired.OffchainConfig.DeltaRound)
+	assert.Nil(t, desired.Oracles)
+	assert.Nil(t, desired.Billing)
+}
+
+func TestCompare(t *testing.T) {
+	state := testState(t)
+	desired := testDesiredDoc(t, state)
+
+	diff, err := Compare(desired, state)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	// drift in every section
+	state.Oracles.Raw[0].Payee = solana.PublicKey{99}
+	state.Oracles.Raw[1].Transmitter = solana.PublicKey{98}
+	state.Oracles.Raw[3].Signer.Key[0] = 5
+	state.Config.MaxAnswer = bin.Int128{Lo: 2000}
+	state.Config.Billing.TransmissionPayment = 3
+	state.Config.BillingAccessController = solana.PublicKey{97}
+	diff, err = Compare(desired, state)
+	require.NoError(t, err)
+
+	fields := map[string]Difference{}
+	for _, d := range diff {
+		fields[d.Field] = d
+	}
+	assert.Len(t, fields, len(diff))
+	assert.Contains(t, fields, "oracles[0100000000000000000000000000000000000000].payee")
+	assert.Contains(t, fields, "oracles[0200000000000000000000000000000000000000].transmitter")
+	assert.Equal(t, "missing", fields["oracles[0400000000000000000000000000000000000000].signer"].Actual)
+	assert.Equal(t, "missing", fields["oracles[0500000000000000000000000000000000000000].signer"].Desired)
+	assert.Equal(t, Difference{Field: "maxAnswer", Desired: "1000", Actual: "2000"}, fields["maxAnswer"])
+	assert.Equal(t, Difference{Field: "billing.transmissionPayment", Desired: "2", Actual: "3"}, fields["billing.transmissionPayment"])
+	assert.Contains(t, fields, "billingAccessController")
+	assert.Len(t, diff, 7)
+
+	// answer bounds are compared numerically
+	state = testState(t)
+	minAnswer, maxAnswer := "+1", "01000"
+	desired.MinAnswer, desired.MaxAnswer = &minAnswer, &maxAnswer
+	diff, err = Compare(desired, state)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+	minAnswer = "-1"
+	diff, err = Compare(desired, state)
+	require.NoError(t, err)
+	assert.Equal(t, Diff{{Field: "minAnswer", Desired: "-1", Actual: "1"}}, diff)
+	minAnswer = "one"
+	_, err = Compare(desired, state)
+	assert.Error(t, err)
+	desired = testDesiredDoc(t, state)
+
+	// duplicate signers are rejected
+	duplicate := desired
+	duplicate.Oracles = append([]Oracle{}, desired.Oracles...)
+	duplicate.Oracles[1].Signer = duplicate.Oracles[0].Signer
+	_, err = Compare(duplicate, state)
+	assert.Error(t, err)
+
+	// offchain parameters
+	*desired.OffchainConfig.DeltaRound = Duration(2 * time.Second)
+	*desired.OffchainConfig.AlphaReportPPB = 5
+	diff, err = Compare(desired, state)
+	require.NoError(t, err)
+	assert.Equal(t, Diff{
+		{Field: "offchainConfig.deltaRound", Desired: "2s", Actual: "1s"},
+		{Field: "offchainConfig.alphaReportPPB", Desired: "5", Actual: "1000"},
+	}, diff)
+
+	// undecodable offchain config is reported as drift
+	state.OffchainConfig.Len = 10
+	diff, err = Compare(desired, state)
+	require.NoError(t, err)
+	require.Len(t, diff, 1)
+	assert.Equal(t, "offchainConfig", diff[0].Field)
+}
+
+func TestCheck(t *testing.T) {
+	state := testState(t)
+	programID, stateID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	var buf bytes.Buffer
+	require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	require.NoError(t, err)
+
+	reader := new(mocks.ReaderWriter)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, stateID, mock.Anything).Return(&rpc.GetAccountInfoResult{
+		Value: &rpc.Account{Owner: programID, Data: data},
+	}, nil)
+
+	f := uint8(2)
+	diff, err := Check(context.Background(), reader, programID, stateID, Desired{F: &f}, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, Diff{{Field: "f", Desired: "2", Actual: "1"}}, diff)
+
+	// state account of another program
+	_, err = Check(context.Background(), reader, solana.NewWallet().PublicKey(), stateID, Desired{F: &f}, rpc.CommitmentConfirmed)
+	var ownerErr *relaySol.AccountOwnerError
+	assert.ErrorAs(t, err, &ownerErr)
+}
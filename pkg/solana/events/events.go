@@ -0,0 +1,147 @@
+// Package events decodes the anchor events emitted by the OCR2 program (programs/ocr2/src/event.rs)
+// from transaction logs and fetches them for an account.
+package events
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
+)
+
+// Event is a decoded OCR2 program event
+type Event interface {
+	EventName() string
+}
+
+// SetConfig is emitted when a config proposal is accepted
+type SetConfig struct {
+	ConfigDigest [32]byte
+	F            uint8
+	Signers      [][20]byte
+}
+
+// SetBilling is emitted when the billing config changes
+type SetBilling struct {
+	ObservationPaymentGjuels  uint32
+	TransmissionPaymentGjuels uint32
+}
+
+// RoundRequested is emitted by request_new_round
+type RoundRequested struct {
+	ConfigDigest [32]byte
+	Requester    solana.PublicKey
+	Epoch        uint32
+	Round        uint8
+}
+
+// NewTransmission is emitted for every accepted report
+type NewTransmission struct {
+	RoundID               uint32
+	ConfigDigest          [32]byte
+	Answer                bin.Int128
+	Transmitter           uint8 // index in the oracle set
+	ObservationsTimestamp uint32
+	ObserverCount         uint8
+	Observers             [19]uint8
+	JuelsPerLamport       uint64
+	ReimbursementGjuels   uint64
+}
+
+func (SetConfig) EventName() string       { return "SetConfig" }
+func (SetBilling) EventName() string      { return "SetBilling" }
+func (RoundRequested) EventName() string  { return "RoundRequested" }
+func (NewTransmission) EventName() string { return "NewTransmission" }
+
+// Discriminator returns the anchor event discriminator: the first 8 bytes of sha256("event:<name>")
+func Discriminator(name string) [8]byte {
+	var d [8]byte
+	h := sha256.Sum256([]byte("event:" + name))
+	copy(d[:], h[:8])
+	return d
+}
+
+// decoders create an empty event for each known discriminator
+var decoders = map[[8]byte]func() Event{
+	Discriminator(SetConfig{}.EventName()):       func() Event { return &SetConfig{} },
+	Discriminator(SetBilling{}.EventName()):      func() Event { return &SetBilling{} },
+	Discriminator(RoundRequested{}.EventName()):  func() Event { return &RoundRequested{} },
+	Discriminator(NewTransmission{}.EventName()): func() Event { return &NewTransmission{} },
+}
+
+// ErrUnknownEvent is returned by DecodeEvent for data not matching a known event discriminator
+var ErrUnknownEvent = errors.New("unknown event discriminator")
+
+// DecodeEvent decodes discriminator prefixed event data into a pointer to one of the event types
+func DecodeEvent(data []byte) (Event, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("event data too short: %d bytes", len(data))
+	}
+	var d [8]byte
+	copy(d[:], data[:8])
+	newEvent, ok := decoders[d]
+	if !ok {
+		return nil, ErrUnknownEvent
+	}
+	event := newEvent()
+	if err := bin.NewBorshDecoder(data[8:]).Decode(event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", event.EventName(), err)
+	}
+	return event, nil
+}
+
+const (
+	logPrefixProgram = "Program "
+	logPrefixData    = "Program data: "
+)
+
+// ParseLogs decodes the events emitted by programID from transaction log messages.
+// Invocations are tracked so that events logged by other programs, including CPIs, are ignored.
+// Unknown events are skipped.
+func ParseLogs(programID solana.PublicKey, logs []string) ([]Event, error) {
+	var stack []string
+	var events []Event
+	program := programID.String()
+	for _, line := range logs {
+		if strings.HasPrefix(line, logPrefixData) {
+			if len(stack) == 0 || stack[len(stack)-1] != program {
+				continue
+			}
+			// sol_log_data fields are base64 encoded and space separated, anchor logs a single field
+			for _, field := range strings.Fields(strings.TrimPrefix(line, logPrefixData)) {
+				data, err := base64.StdEncoding.DecodeString(field)
+				if err != nil {
+					return nil, fmt.Errorf("invalid program data log %q: %w", line, err)
+				}
+				event, err := DecodeEvent(data)
+				if errors.Is(err, ErrUnknownEvent) {
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
+			continue
+		}
+
+		// "Program <id> invoke [n]", "Program <id> success", "Program <id> failed: <err>"
+		fields := strings.Fields(strings.TrimPrefix(line, logPrefixProgram))
+		if !strings.HasPrefix(line, logPrefixProgram) || len(fields) < 2 {
+			continue
+		}
+		switch {
+		case fields[1] == "invoke":
+			stack = append(stack, fields[0])
+		case fields[1] == "success" || strings.HasPrefix(fields[1], "failed"):
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return events, nil
+}
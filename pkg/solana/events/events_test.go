@@ -0,0 +1,96 @@
+package events
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeEvent returns the discriminator prefixed borsh encoding of event
+func encodeEvent(t *testing.T, event Event) []byte {
+	d := Discriminator(event.EventName())
+	buf := bytes.NewBuffer(d[:])
+	require.NoError(t, bin.NewBorshEncoder(buf).Encode(event))
+	return buf.Bytes()
+}
+
+// dataLog returns the "Program data:" log line of event
+func dataLog(t *testing.T, event Event) string {
+	return logPrefixData + base64.StdEncoding.EncodeToString(encodeEvent(t, event))
+}
+
+func TestDiscriminator(t *testing.T) {
+	// anchor event discriminators, sha256("event:<name>")[:8]
+	assert.Equal(t, [8]byte{130, 54, 203, 77, 30, 107, 79, 168}, Discriminator("NewTransmission"))
+}
+
+func TestDecodeEvent(t *testing.T) {
+	for _, event := range []Event{
+		&SetConfig{ConfigDigest: [32]byte{1}, F: 1, Signers: [][20]byte{{2}, {3}, {4}, {5}}},
+		&SetBilling{ObservationPaymentGjuels: 1, TransmissionPaymentGjuels: 2},
+		&RoundRequested{ConfigDigest: [32]byte{1}, Requester: solana.PublicKey{2}, Epoch: 3, Round: 4},
+		&NewTransmission{
+			RoundID:               1,
+			ConfigDigest:          [32]byte{2},
+			Answer:                bin.Int128{Lo: 3, Hi: 4},
+			Transmitter:           5,
+			ObservationsTimestamp: 6,
+			ObserverCount:         2,
+			Observers:             [19]uint8{0, 1},
+			JuelsPerLamport:       7,
+			ReimbursementGjuels:   8,
+		},
+	} {
+		decoded, err := DecodeEvent(encodeEvent(t, event))
+		require.NoError(t, err, event.EventName())
+		assert.Equal(t, event, decoded)
+	}
+
+	_, err := DecodeEvent(make([]byte, 7))
+	assert.Error(t, err)
+	_, err = DecodeEvent(make([]byte, 16))
+	assert.ErrorIs(t, err, ErrUnknownEvent)
+	// truncated event
+	data := encodeEvent(t, &SetBilling{})
+	_, err = DecodeEvent(data[:len(data)-1])
+	assert.Error(t, err)
+}
+
+func TestParseLogs(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	storeID := solana.NewWallet().PublicKey()
+	transmission := &NewTransmission{RoundID: 1}
+	billing := &SetBilling{ObservationPaymentGjuels: 1}
+	storeEvent := &SetBilling{ObservationPaymentGjuels: 2}
+
+	logs := []string{
+		"Program " + programID.String() + " invoke [1]",
+		"Program log: Instruction: Transmit",
+		"Program " + storeID.String() + " invoke [2]",
+		dataLog(t, storeEvent), // logged by the store program
+		"Program " + storeID.String() + " consumed 100 of 200000 compute units",
+		"Program " + storeID.String() + " success",
+		dataLog(t, transmission),
+		logPrefixData + base64.StdEncoding.EncodeToString(make([]byte, 16)), // unknown event
+		"Program " + programID.String() + " consumed 1000 of 200000 compute units",
+		"Program " + programID.String() + " success",
+		dataLog(t, storeEvent), // outside of any invocation
+		"Program " + programID.String() + " invoke [1]",
+		dataLog(t, billing),
+		"Program " + programID.String() + " success",
+	}
+	events, err := ParseLogs(programID, logs)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{transmission, billing}, events)
+
+	_, err = ParseLogs(programID, []string{
+		"Program " + programID.String() + " invoke [1]",
+		logPrefixData + "!",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,250 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+// MaxPageSize is the maximum number of signatures returned by getSignaturesForAddress
+const MaxPageSize = 1000
+
+// maxAtSlotPages bounds the signature pages AtSlot scans back for a slot
+const maxAtSlotPages = 10
+
+// ErrSlotOutOfReach is returned by AtSlot when the slot is older than the history it scans
+var ErrSlotOutOfReach = errors.New("slot out of reach of the scanned transaction history")
+
+// Client is the subset of the RPC client used by the Fetcher, it is satisfied by the relay client
+type Client interface {
+	GetSignaturesForAddressWithOpts(ctx context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error)
+	GetTransaction(ctx context.Context, txSig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error)
+}
+
+// TxEvents are the events emitted by a single transaction, in emission order
+type TxEvents struct {
+	Signature solana.Signature
+	Slot      uint64
+	BlockTime *solana.UnixTimeSeconds
+	Events    []Event
+}
+
+// Fetcher reads the events of the OCR2 program from the transactions of an account,
+// usually the feed state account.
+type Fetcher struct {
+	client     Client
+	programID  solana.PublicKey
+	account    solana.PublicKey
+	commitment rpc.CommitmentType
+	pageSize   int
+	maxPages   int // signature pages AtSlot scans back
+	lggr       logger.Logger
+}
+
+// NewFetcher returns a Fetcher for programID events in transactions involving account
+func NewFetcher(c Client, programID, account solana.PublicKey, commitment rpc.CommitmentType, lggr logger.Logger) *Fetcher {
+	return &Fetcher{
+		client:     c,
+		programID:  programID,
+		account:    account,
+		commitment: commitment,
+		pageSize:   MaxPageSize,
+		maxPages:   maxAtSlotPages,
+		lggr:       lggr,
+	}
+}
+
+// Page returns the events of up to limit transactions before the before signature, newest first.
+// A zero before starts from the latest transaction. The returned cursor is passed as before to fetch the next page,
+// it is zero once the history is exhausted. Every transaction is returned, failed transactions have no events.
+func (f *Fetcher) Page(ctx context.Context, before solana.Signature, limit int) ([]TxEvents, solana.Signature, error) {
+	if limit <= 0 || limit > MaxPageSize {
+		return nil, solana.Signature{}, fmt.Errorf("page limit %d out of range (1..%d)", limit, MaxPageSize)
+	}
+	sigs, err := f.signatures(ctx, before, solana.Signature{}, limit)
+	if err != nil {
+		return nil, solana.Signature{}, err
+	}
+	var out []TxEvents
+	for _, sig := range sigs {
+		tx, err := f.fetch(ctx, sig)
+		if err != nil {
+			return nil, solana.Signature{}, err
+		}
+		out = append(out, tx)
+	}
+	var cursor solana.Signature
+	if len(sigs) == limit {
+		cursor = sigs[len(sigs)-1].Signature
+	}
+	return out, cursor, nil
+}
+
+// Since returns the events of all transactions after the until signature, oldest first.
+// A zero until returns the whole history. The returned cursor is the latest transaction seen,
+// with or without events, and is passed as until to resume.
+func (f *Fetcher) Since(ctx context.Context, until solana.Signature) ([]TxEvents, solana.Signature, error) {
+	return f.since(ctx, until, time.Time{})
+}
+
+// SinceTime is Since limited to transactions with a block time at or after start,
+// it bounds the history scanned when there is no cursor yet.
+func (f *Fetcher) SinceTime(ctx context.Context, until solana.Signature, start time.Time) ([]TxEvents, solana.Signature, error) {
+	return f.since(ctx, until, start)
+}
+
+func (f *Fetcher) since(ctx context.Context, until solana.Signature, start time.Time) ([]TxEvents, solana.Signature, error) {
+	// signatures are returned newest first, page back until the cursor or start time is reached
+	var sigs []*rpc.TransactionSignature
+	var before solana.Signature
+	cursor := until
+	for done := false; !done; {
+		page, err := f.signatures(ctx, before, until, f.pageSize)
+		if err != nil {
+			return nil, until, err
+		}
+		if before.IsZero() && len(page) > 0 {
+			cursor = page[0].Signature
+		}
+		for _, sig := range page {
+			if sig.BlockTime != nil && sig.BlockTime.Time().Before(start) {
+				done = true
+				break
+			}
+			sigs = append(sigs, sig)
+		}
+		if len(page) < f.pageSize {
+			break
+		}
+		before = page[len(page)-1].Signature
+	}
+	var out []TxEvents
+	for i := len(sigs) - 1; i >= 0; i-- {
+		tx, err := f.fetch(ctx, sigs[i])
+		if err != nil {
+			return nil, until, err
+		}
+		if len(tx.Events) > 0 {
+			out = append(out, tx)
+		}
+	}
+	return out, cursor, nil
+}
+
+// AtSlot returns the events of the transactions in the given slot, in execution order.
+// Signatures are paged back from the latest transaction, only transactions in the slot are fetched.
+// At most maxAtSlotPages pages are scanned, an older slot returns ErrSlotOutOfReach.
+func (f *Fetcher) AtSlot(ctx context.Context, slot uint64) ([]TxEvents, error) {
+	var sigs []*rpc.TransactionSignature
+	var before solana.Signature
+	for pages, done := 0, false; !done; pages++ {
+		if pages == f.maxPages {
+			return nil, fmt.Errorf("%w: slot %d not reached within the latest %d transactions of account %s", ErrSlotOutOfReach, slot, pages*f.pageSize, f.account)
+		}
+		page, err := f.signatures(ctx, before, solana.Signature{}, f.pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, sig := range page {
+			if sig.Slot < slot {
+				done = true
+				break
+			}
+			if sig.Slot == slot {
+				sigs = append(sigs, sig)
+			}
+		}
+		if len(page) < f.pageSize {
+			break
+		}
+		before = page[len(page)-1].Signature
+	}
+
+	var out []TxEvents
+	for i := len(sigs) - 1; i >= 0; i-- {
+		tx, err := f.fetch(ctx, sigs[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(tx.Events) > 0 {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
+// Stream polls for new transactions after cursor and calls handle for each transaction with events, oldest first.
+// Callers persist TxEvents.Signature once handled to resume from it. Stream returns when ctx is done or handle errors.
+func (f *Fetcher) Stream(ctx context.Context, cursor solana.Signature, pollPeriod time.Duration, handle func(TxEvents) error) error {
+	tick := time.NewTicker(pollPeriod)
+	defer tick.Stop()
+	for {
+		txs, next, err := f.Since(ctx, cursor)
+		if err != nil && ctx.Err() == nil {
+			// transient RPC errors are retried on the next poll from the same cursor
+			f.lggr.Warnf("error in Stream.Since for account %s: %s", f.account, err)
+		}
+		if err == nil {
+			for _, tx := range txs {
+				if err := handle(tx); err != nil {
+					return err
+				}
+			}
+			cursor = next
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
+
+func (f *Fetcher) signatures(ctx context.Context, before, until solana.Signature, limit int) ([]*rpc.TransactionSignature, error) {
+	sigs, err := f.client.GetSignaturesForAddressWithOpts(ctx, f.account, &rpc.GetSignaturesForAddressOpts{
+		Limit:      &limit,
+		Before:     before,
+		Until:      until,
+		Commitment: f.commitment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch signatures for account %s: %w", f.account, err)
+	}
+	return sigs, nil
+}
+
+// fetch decodes the events of a transaction, failed transactions have their effects and events reverted
+func (f *Fetcher) fetch(ctx context.Context, sig *rpc.TransactionSignature) (TxEvents, error) {
+	out := TxEvents{Signature: sig.Signature, Slot: sig.Slot, BlockTime: sig.BlockTime}
+	if sig.Err != nil {
+		return out, nil
+	}
+	// v0 transmits are listed for the state account, the RPC fails on them unless versioned transactions are requested
+	version := uint64(0)
+	res, err := f.client.GetTransaction(ctx, sig.Signature, &client.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     f.commitment,
+		MaxSupportedTransactionVersion: &version,
+	})
+	if err != nil {
+		return TxEvents{}, fmt.Errorf("failed to fetch transaction %s: %w", sig.Signature, err)
+	}
+	if res == nil || res.Meta == nil {
+		return TxEvents{}, errors.New("nil pointer returned in fetch.GetTransaction")
+	}
+	if res.Meta.Err != nil {
+		return out, nil
+	}
+	out.Events, err = ParseLogs(f.programID, res.Meta.LogMessages)
+	if err != nil {
+		return TxEvents{}, fmt.Errorf("failed to parse logs of transaction %s: %w", sig.Signature, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,278 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+type testTx struct {
+	sig       solana.Signature
+	logs      []string
+	failed    bool
+	versioned bool
+}
+
+// testClient serves the transactions of a single account, oldest first
+type testClient struct {
+	lock sync.Mutex
+	txs  []testTx
+	err  error
+}
+
+func (c *testClient) add(logs []string, failed bool) solana.Signature {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	sig := solana.Signature{byte(len(c.txs) + 1)}
+	c.txs = append(c.txs, testTx{sig: sig, logs: logs, failed: failed})
+	return sig
+}
+
+func (c *testClient) GetSignaturesForAddressWithOpts(_ context.Context, _ solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	var out []*rpc.TransactionSignature
+	started := opts.Before.IsZero()
+	for i := len(c.txs) - 1; i >= 0 && len(out) < *opts.Limit; i-- {
+		tx := c.txs[i]
+		if !started {
+			started = tx.sig == opts.Before
+			continue
+		}
+		if tx.sig == opts.Until {
+			break
+		}
+		blockTime := solana.UnixTimeSeconds(1000 + i)
+		sig := &rpc.TransactionSignature{Signature: tx.sig, Slot: uint64(i), BlockTime: &blockTime}
+		if tx.failed {
+			sig.Err = "failed"
+		}
+		out = append(out, sig)
+	}
+	return out, nil
+}
+
+func (c *testClient) GetTransaction(_ context.Context, txSig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i, tx := range c.txs {
+		if tx.sig == txSig {
+			if tx.versioned && (opts == nil || opts.MaxSupportedTransactionVersion == nil) {
+				return nil, errors.New("Transaction version (0) is not supported by the requesting client")
+			}
+			return &rpc.GetTransactionResult{Slot: uint64(i), Meta: &rpc.TransactionMeta{LogMessages: tx.logs}}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+// testTransmission adds a transaction emitting a NewTransmission event for round
+func testTransmission(t *testing.T, c *testClient, programID solana.PublicKey, round uint32) solana.Signature {
+	return c.add([]string{
+		"Program " + programID.String() + " invoke [1]",
+		dataLog(t, &NewTransmission{RoundID: round}),
+		"Program " + programID.String() + " success",
+	}, false)
+}
+
+// testTransmissionV0 adds a v0 transaction emitting a NewTransmission event for round
+func testTransmissionV0(t *testing.T, c *testClient, programID solana.PublicKey, round uint32) solana.Signature {
+	sig := testTransmission(t, c, programID, round)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.txs[len(c.txs)-1].versioned = true
+	return sig
+}
+
+func rounds(txs []TxEvents) []uint32 {
+	var out []uint32
+	for _, tx := range txs {
+		for _, e := range tx.Events {
+			out = append(out, e.(*NewTransmission).RoundID)
+		}
+	}
+	return out
+}
+
+func TestFetcher_Page(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	c := &testClient{}
+	for i := uint32(1); i <= 5; i++ {
+		testTransmission(t, c, programID, i)
+	}
+	c.add(nil, false) // transaction without events
+	f := NewFetcher(c, programID, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed, logger.TestLogger(t))
+
+	txs, cursor, err := f.Page(context.Background(), solana.Signature{}, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{5, 4}, rounds(txs))
+	require.False(t, cursor.IsZero())
+
+	txs, cursor, err = f.Page(context.Background(), cursor, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{3, 2, 1}, rounds(txs))
+	require.False(t, cursor.IsZero())
+
+	txs, cursor, err = f.Page(context.Background(), cursor, 3)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.True(t, cursor.IsZero())
+
+	_, _, err = f.Page(context.Background(), solana.Signature{}, MaxPageSize+1)
+	assert.Error(t, err)
+}
+
+func TestFetcher_V0(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	c := &testClient{}
+	testTransmission(t, c, programID, 1)
+	testTransmissionV0(t, c, programID, 2)
+	testTransmissionV0(t, c, programID, 3)
+	f := NewFetcher(c, programID, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed, logger.TestLogger(t))
+
+	// legacy and v0 transmits are fetched
+	txs, _, err := f.Page(context.Background(), solana.Signature{}, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{3, 2, 1}, rounds(txs))
+
+	txs, _, err = f.Since(context.Background(), solana.Signature{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 3}, rounds(txs))
+
+	txs, err = f.AtSlot(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{2}, rounds(txs))
+}
+
+func TestFetcher_Since(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	c := &testClient{}
+	f := NewFetcher(c, programID, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed, logger.TestLogger(t))
+	f.pageSize = 2
+
+	txs, cursor, err := f.Since(context.Background(), solana.Signature{})
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.True(t, cursor.IsZero())
+
+	first := testTransmission(t, c, programID, 1)
+	testTransmission(t, c, programID, 2)
+	c.add([]string{
+		"Program " + programID.String() + " invoke [1]",
+		dataLog(t, &NewTransmission{RoundID: 99}),
+		"Program " + programID.String() + " failed: custom program error",
+	}, true)
+	testTransmission(t, c, programID, 3)
+	testTransmission(t, c, programID, 4)
+	last := c.add(nil, false)
+
+	// whole history oldest first, failed transactions omitted
+	txs, cursor, err = f.Since(context.Background(), solana.Signature{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 3, 4}, rounds(txs))
+	assert.Equal(t, last, cursor)
+	assert.Equal(t, first, txs[0].Signature)
+
+	// resume from a cursor
+	txs, cursor, err = f.Since(context.Background(), txs[1].Signature)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{3, 4}, rounds(txs))
+	assert.Equal(t, last, cursor)
+
+	// nothing new
+	txs, cursor, err = f.Since(context.Background(), cursor)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.Equal(t, last, cursor)
+
+	// bounded by block time, the cursor is the latest transaction
+	txs, cursor, err = f.SinceTime(context.Background(), solana.Signature{}, time.Unix(1003, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{3, 4}, rounds(txs))
+	assert.Equal(t, last, cursor)
+	txs, cursor, err = f.SinceTime(context.Background(), solana.Signature{}, time.Unix(2000, 0))
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+	assert.Equal(t, last, cursor)
+}
+
+func TestFetcher_Stream(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	c := &testClient{}
+	f := NewFetcher(c, programID, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed, logger.TestLogger(t))
+	cursor := testTransmission(t, c, programID, 1)
+	testTransmission(t, c, programID, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan TxEvents)
+	done := make(chan error)
+	go func() {
+		done <- f.Stream(ctx, cursor, time.Millisecond, func(tx TxEvents) error {
+			received <- tx
+			return nil
+		})
+	}()
+
+	// resumes after the cursor
+	assert.Equal(t, []uint32{2}, rounds([]TxEvents{<-received}))
+
+	// transient errors are retried
+	c.lock.Lock()
+	c.err = errors.New("rpc unavailable")
+	c.lock.Unlock()
+	testTransmission(t, c, programID, 3)
+	time.Sleep(10 * time.Millisecond)
+	c.lock.Lock()
+	c.err = nil
+	c.lock.Unlock()
+	assert.Equal(t, []uint32{3}, rounds([]TxEvents{<-received}))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// handler errors stop the stream
+	handlerErr := errors.New("handler")
+	err := f.Stream(context.Background(), cursor, time.Millisecond, func(TxEvents) error { return handlerErr })
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+func TestFetcher_AtSlot(t *testing.T) {
+	programID := solana.NewWallet().PublicKey()
+	c := &testClient{}
+	f := NewFetcher(c, programID, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed, logger.TestLogger(t))
+	f.pageSize = 2
+	for i := uint32(0); i < 5; i++ {
+		testTransmission(t, c, programID, i) // slot i
+	}
+
+	txs, err := f.AtSlot(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, rounds(txs))
+	assert.Equal(t, uint64(1), txs[0].Slot)
+
+	txs, err = f.AtSlot(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+
+	// the scan back is bounded
+	f.maxPages = 2
+	txs, err = f.AtSlot(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{2}, rounds(txs))
+	_, err = f.AtSlot(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrSlotOutOfReach)
+}
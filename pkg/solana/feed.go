@@ -0,0 +1,365 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+// ErrRoundNotFound is returned for rounds outside the live and historical ring buffers of a feed
+var ErrRoundNotFound = errors.New("round not found")
+
+// maxRoundReadAttempts bounds the re-reads of rounds overwritten by new transmissions while being read
+const maxRoundReadAttempts = 3
+
+// Feed reads rounds from a store program transmissions account. The account keeps a live ring buffer with
+// every round and a historical ring buffer with every granularity-th round.
+type Feed struct {
+	reader     client.AccountReader
+	program    solana.PublicKey
+	account    solana.PublicKey
+	commitment rpc.CommitmentType
+
+	// historical ring buffer length, the account size is fixed at creation and the layout by version
+	historicalLen        *uint32
+	historicalLenVersion uint8
+	historicalLenLock    sync.Mutex
+
+	// location of the latest transmission, the next one is looked up around it
+	latest     *latestLocation
+	latestLock sync.Mutex
+}
+
+// NewFeed returns a reader of the transmissions account, the account must be owned by storeProgramID
+func NewFeed(reader client.AccountReader, storeProgramID, account solana.PublicKey, commitment rpc.CommitmentType) *Feed {
+	return &Feed{
+		reader:     reader,
+		program:    storeProgramID,
+		account:    account,
+		commitment: commitment,
+	}
+}
+
+// Round is a transmission stored in a feed
+type Round struct {
+	RoundID   uint32
+	Slot      uint64
+	Timestamp uint32
+	Answer    *big.Int
+}
+
+// GetRound returns the round like the store program fetch: rounds in the live buffer are returned as is,
+// older rounds are rounded down to the closest round in the historical buffer.
+func GetRound(ctx context.Context, feed *Feed, roundID uint32) (Round, error) {
+	rounds, err := GetRounds(ctx, feed, roundID, roundID)
+	if err != nil {
+		return Round{}, err
+	}
+	if len(rounds) == 0 {
+		return Round{}, ErrRoundNotFound
+	}
+	return rounds[0], nil
+}
+
+// GetRounds returns the distinct rounds fetched for round IDs from to to, in ascending order.
+// Contiguous entries are read with a single data slice. Rounds overwritten while being read are read again.
+func GetRounds(ctx context.Context, feed *Feed, from, to uint32) ([]Round, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid round range: from %d > to %d", from, to)
+	}
+	header, err := feed.header(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; attempt < maxRoundReadAttempts; attempt++ {
+		historicalLen, err := feed.historicalLength(ctx, header)
+		if err != nil {
+			return nil, err
+		}
+		locations := locateRounds(header, historicalLen, from, to)
+		transmissions, err := feed.read(ctx, header, locations)
+		if err != nil {
+			return nil, err
+		}
+
+		// entries stay valid as long as the rounds are stored at the same location after the read
+		next, err := feed.header(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !sameLocations(next, historicalLen, locations) {
+			header = next
+			continue
+		}
+
+		var rounds []Round
+		for i, l := range locations {
+			t := transmissions[i]
+			if t.Slot == 0 && t.Timestamp == 0 {
+				continue // historical buffer not filled yet
+			}
+			rounds = append(rounds, Round{
+				RoundID:   l.roundID,
+				Slot:      t.Slot,
+				Timestamp: t.Timestamp,
+				Answer:    t.Answer.BigInt(),
+			})
+		}
+		return rounds, nil
+	}
+	return nil, fmt.Errorf("rounds %d to %d overwritten while reading feed %s after %d attempts", from, to, feed.account, maxRoundReadAttempts)
+}
+
+// ringBuffer identifies the live or historical ring buffer of a feed
+type ringBuffer uint8
+
+const (
+	liveBuffer ringBuffer = iota
+	historicalBuffer
+)
+
+// roundLocation is where a round is stored in the transmissions account
+type roundLocation struct {
+	buffer  ringBuffer
+	index   uint32
+	roundID uint32 // stored round, rounded down to the granularity in the historical buffer
+}
+
+// locateRound mirrors the store program Feed.fetch
+func locateRound(h TransmissionsHeader, historicalLen uint32, roundID uint32) (roundLocation, bool) {
+	latest := uint64(h.LatestRoundID)
+	round := uint64(roundID)
+	granularity := uint64(h.Granularity)
+	liveLen, histLen := uint64(h.LiveLength), uint64(historicalLen)
+	if round == 0 || latest < round || liveLen == 0 || granularity == 0 {
+		return roundLocation{}, false
+	}
+
+	liveStart := saturatingSub(latest, saturatingSub(liveLen, 1))
+	historicalEnd := latest - latest%granularity
+	historicalStart := saturatingSub(historicalEnd, granularity*saturatingSub(histLen, 1))
+
+	switch {
+	case liveStart <= round:
+		return roundLocation{
+			buffer:  liveBuffer,
+			index:   ringIndex(uint64(h.LiveCursor), latest-round+1, liveLen),
+			roundID: roundID,
+		}, true
+	case histLen > 0 && historicalStart <= round && round <= historicalEnd:
+		stored := round - round%granularity
+		if stored == 0 {
+			return roundLocation{}, false
+		}
+		return roundLocation{
+			buffer:  historicalBuffer,
+			index:   ringIndex(uint64(h.HistoricalCursor), (historicalEnd-stored)/granularity+1, histLen),
+			roundID: uint32(stored),
+		}, true
+	}
+	return roundLocation{}, false
+}
+
+// locateRounds returns the distinct locations of rounds from to to, in ascending round order
+func locateRounds(h TransmissionsHeader, historicalLen uint32, from, to uint32) []roundLocation {
+	if to > h.LatestRoundID {
+		to = h.LatestRoundID
+	}
+	// skip rounds older than both buffers
+	liveStart := saturatingSub(uint64(h.LatestRoundID), saturatingSub(uint64(h.LiveLength), 1))
+	lowest := liveStart
+	if granularity := uint64(h.Granularity); historicalLen > 0 && granularity > 0 {
+		historicalEnd := uint64(h.LatestRoundID) - uint64(h.LatestRoundID)%granularity
+		if historicalStart := saturatingSub(historicalEnd, granularity*uint64(historicalLen-1)); historicalStart < lowest {
+			lowest = historicalStart
+		}
+	}
+
+	var locations []roundLocation
+	start := uint64(from)
+	if start < lowest {
+		start = lowest
+	}
+	for r := start; r <= uint64(to); r++ {
+		l, ok := locateRound(h, historicalLen, uint32(r))
+		if !ok {
+			continue
+		}
+		if next := uint64(l.roundID) + uint64(h.Granularity); l.buffer == historicalBuffer && next <= liveStart {
+			r = next - 1 // rounds until the next stored one round down to it
+		}
+		if len(locations) == 0 || locations[len(locations)-1] != l {
+			locations = append(locations, l)
+		}
+	}
+	return locations
+}
+
+func sameLocations(h TransmissionsHeader, historicalLen uint32, locations []roundLocation) bool {
+	for _, l := range locations {
+		if next, ok := locateRound(h, historicalLen, l.roundID); !ok || next != l {
+			return false
+		}
+	}
+	return true
+}
+
+// ringIndex returns the index offset entries before the cursor
+func ringIndex(cursor, offset, length uint64) uint32 {
+	if cursor >= offset {
+		return uint32(cursor - offset)
+	}
+	return uint32(length - (offset - cursor))
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
+
+// read fetches the transmissions at locations, batching contiguous entries of a buffer into one data slice
+func (f *Feed) read(ctx context.Context, h TransmissionsHeader, locations []roundLocation) ([]Transmission, error) {
+	layout, err := feedLayout(h.Version)
+	if err != nil {
+		return nil, err
+	}
+	liveStart := AccountDiscriminatorLen + TransmissionsHeaderMaxSize
+	bases := map[ringBuffer]uint64{
+		liveBuffer:       liveStart,
+		historicalBuffer: liveStart + uint64(h.LiveLength)*layout.TransmissionLen,
+	}
+
+	entries := map[roundLocation]Transmission{}
+	for buffer, base := range bases {
+		var indexes []uint32
+		for _, l := range locations {
+			if l.buffer == buffer {
+				indexes = append(indexes, l.index)
+			}
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+		for start := 0; start < len(indexes); {
+			end := start
+			for end+1 < len(indexes) && indexes[end+1] == indexes[end]+1 {
+				end++
+			}
+			offset := base + uint64(indexes[start])*layout.TransmissionLen
+			data, _, err := f.slice(ctx, offset, uint64(end-start+1)*layout.TransmissionLen)
+			if err != nil {
+				return nil, err
+			}
+			if uint64(len(data)) < uint64(end-start+1)*layout.TransmissionLen {
+				return nil, fmt.Errorf("transmissions account too short: %d bytes at offset %d", len(data), offset)
+			}
+			for i := start; i <= end; i++ {
+				entry := data[uint64(i-start)*layout.TransmissionLen : uint64(i-start+1)*layout.TransmissionLen]
+				t, err := layout.DecodeTransmission(entry)
+				if err != nil {
+					return nil, err
+				}
+				entries[roundLocation{buffer: buffer, index: indexes[i]}] = t
+			}
+			start = end + 1
+		}
+	}
+
+	out := make([]Transmission, len(locations))
+	for i, l := range locations {
+		out[i] = entries[roundLocation{buffer: l.buffer, index: l.index}]
+	}
+	return out, nil
+}
+
+func (f *Feed) header(ctx context.Context) (TransmissionsHeader, error) {
+	data, _, err := f.slice(ctx, 0, AccountDiscriminatorLen+TransmissionsHeaderLen)
+	if err != nil {
+		return TransmissionsHeader{}, err
+	}
+	header, _, err := decodeTransmissionsHeader(data)
+	return header, withAccount(err, f.account)
+}
+
+// GetFeedHeader fetches the header of a transmissions account, the account must be owned by storeProgramID
+func GetFeedHeader(ctx context.Context, reader client.AccountReader, storeProgramID, account solana.PublicKey, commitment rpc.CommitmentType) (FeedHeader, uint64, error) {
+	offset, length := uint64(0), AccountDiscriminatorLen+TransmissionsHeaderLen
+	res, err := reader.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Encoding:   "base64",
+		Commitment: commitment,
+		DataSlice: &rpc.DataSlice{
+			Offset: &offset,
+			Length: &length,
+		},
+	})
+	if err != nil {
+		return FeedHeader{}, 0, fmt.Errorf("failed to fetch transmissions account at address '%s': %w", account, err)
+	}
+	if res == nil || res.Value == nil || res.Value.Data == nil {
+		return FeedHeader{}, 0, errors.New("nil pointer returned in GetFeedHeader.GetAccountInfoWithOpts")
+	}
+	if err := checkOwner(account, res.Value, storeProgramID); err != nil {
+		return FeedHeader{}, 0, err
+	}
+	header, _, err := decodeTransmissionsHeader(res.Value.Data.GetBinary())
+	if err != nil {
+		return FeedHeader{}, 0, withAccount(err, account)
+	}
+	return NewFeedHeader(header), res.RPCContext.Context.Slot, nil
+}
+
+// historicalLength returns the length of the historical buffer from the account size, with a single read per feed.
+// The pinned RPC client does not expose the account space, so the buffer is read once and its data length used.
+func (f *Feed) historicalLength(ctx context.Context, h TransmissionsHeader) (uint32, error) {
+	f.historicalLenLock.Lock()
+	defer f.historicalLenLock.Unlock()
+	if f.historicalLen != nil && f.historicalLenVersion == h.Version {
+		return *f.historicalLen, nil
+	}
+	layout, err := feedLayout(h.Version)
+	if err != nil {
+		return 0, err
+	}
+
+	// data slices are truncated to the account size
+	base := AccountDiscriminatorLen + TransmissionsHeaderMaxSize + uint64(h.LiveLength)*layout.TransmissionLen
+	data, _, err := f.slice(ctx, base, uint64(math.MaxUint32)*layout.TransmissionLen)
+	if err != nil {
+		return 0, err
+	}
+	n := uint32(uint64(len(data)) / layout.TransmissionLen)
+	f.historicalLen = &n
+	f.historicalLenVersion = h.Version
+	return n, nil
+}
+
+// slice reads a data slice of the transmissions account, it returns the data and the slot it was read at
+func (f *Feed) slice(ctx context.Context, offset, length uint64) ([]byte, uint64, error) {
+	res, err := f.reader.GetAccountInfoWithOpts(ctx, f.account, &rpc.GetAccountInfoOpts{
+		Encoding:   "base64",
+		Commitment: f.commitment,
+		DataSlice: &rpc.DataSlice{
+			Offset: &offset,
+			Length: &length,
+		},
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch transmissions account at address '%s': %w", f.account, err)
+	}
+	if res == nil || res.Value == nil || res.Value.Data == nil {
+		return nil, 0, errors.New("nil pointer returned in Feed.GetAccountInfoWithOpts")
+	}
+	if err := checkOwner(f.account, res.Value, f.program); err != nil {
+		return nil, 0, err
+	}
+	return res.Value.Data.GetBinary(), res.RPCContext.Context.Slot, nil
+}
@@ -0,0 +1,231 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"math"
+	"testing"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storeprogram "github.com/smartcontractkit/chainlink-solana/contracts/generated/store"
+)
+
+// testStore mirrors the store program ring buffers and serves data slices of the transmissions account
+type testStore struct {
+	t          *testing.T
+	program    solana.PublicKey
+	header     TransmissionsHeader
+	live       []Transmission
+	historical []Transmission
+
+	calls   int
+	onSlice func(calls int) // called before serving each slice
+}
+
+func newTestStore(t *testing.T, liveLen, historicalLen int, granularity uint8) *testStore {
+	return &testStore{
+		t:          t,
+		program:    solana.NewWallet().PublicKey(),
+		header:     TransmissionsHeader{Version: 2, Granularity: granularity, LiveLength: uint32(liveLen)},
+		live:       make([]Transmission, liveLen),
+		historical: make([]Transmission, historicalLen),
+	}
+}
+
+// insert mirrors the store program Feed.insert
+func (s *testStore) insert(n int) {
+	for i := 0; i < n; i++ {
+		s.header.LatestRoundID++
+		round := s.header.LatestRoundID
+		t := Transmission{Slot: uint64(round), Timestamp: round, Answer: bin.Int128{Lo: uint64(round)}}
+		s.live[s.header.LiveCursor] = t
+		s.header.LiveCursor = (s.header.LiveCursor + 1) % uint32(len(s.live))
+		if round%uint32(s.header.Granularity) == 0 {
+			s.historical[s.header.HistoricalCursor] = t
+			s.header.HistoricalCursor = (s.header.HistoricalCursor + 1) % uint32(len(s.historical))
+		}
+	}
+}
+
+func (s *testStore) data() []byte {
+	var buf bytes.Buffer
+	buf.Write(storeprogram.TransmissionsDiscriminator[:])
+	require.NoError(s.t, bin.NewBinEncoder(&buf).Encode(s.header))
+	buf.Write(make([]byte, AccountDiscriminatorLen+TransmissionsHeaderMaxSize-uint64(buf.Len())))
+	for _, t := range append(append([]Transmission{}, s.live...), s.historical...) {
+		if s.header.Version == 1 {
+			require.NoError(s.t, bin.NewBinEncoder(&buf).Encode(TransmissionV1{Timestamp: uint64(t.Timestamp), Answer: t.Answer}))
+			continue
+		}
+		require.NoError(s.t, bin.NewBinEncoder(&buf).Encode(t))
+	}
+	return buf.Bytes()
+}
+
+func (s *testStore) GetAccountInfoWithOpts(_ context.Context, _ solana.PublicKey, opts *rpc.GetAccountInfoOpts) (*rpc.GetAccountInfoResult, error) {
+	s.calls++
+	if s.onSlice != nil {
+		s.onSlice(s.calls)
+	}
+	data := s.data()
+	start, end := *opts.DataSlice.Offset, *opts.DataSlice.Offset+*opts.DataSlice.Length
+	if start > uint64(len(data)) {
+		start = uint64(len(data))
+	}
+	if end > uint64(len(data)) {
+		end = uint64(len(data))
+	}
+	raw, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(data[start:end]))
+	require.NoError(s.t, err)
+	return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: s.program, Data: raw}}, nil
+}
+
+func TestGetRound(t *testing.T) {
+	// same scenario as the store program transmissions test
+	store := newTestStore(t, 2, 3, 5)
+	store.insert(20)
+	feed := NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+
+	for requested, expected := range map[uint32]uint32{
+		20: 20, 19: 19, // live range returns the precise round
+		18: 15, 15: 15, 14: 10, 10: 10, // historical range rounds down
+	} {
+		round, err := GetRound(context.Background(), feed, requested)
+		require.NoError(t, err, "round %d", requested)
+		assert.Equal(t, expected, round.RoundID)
+		assert.Equal(t, uint64(expected), round.Slot)
+		assert.Equal(t, expected, round.Timestamp)
+		assert.Equal(t, int64(expected), round.Answer.Int64())
+	}
+	rounds, err := GetRounds(context.Background(), feed, 16, 20)
+	require.NoError(t, err)
+	require.Len(t, rounds, 3)
+	assert.Equal(t, []uint32{15, 19, 20}, []uint32{rounds[0].RoundID, rounds[1].RoundID, rounds[2].RoundID})
+
+	for _, requested := range []uint32{0, 9, 21} {
+		_, err := GetRound(context.Background(), feed, requested)
+		assert.ErrorIs(t, err, ErrRoundNotFound, "round %d", requested)
+	}
+	require.NotNil(t, feed.historicalLen)
+	assert.Equal(t, uint32(3), *feed.historicalLen)
+
+	// the historical length is read once per feed
+	feed = NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	store.calls = 0
+	_, err = GetRound(context.Background(), feed, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 4, store.calls) // header, historical length, slice and header check
+	store.calls = 0
+	_, err = GetRound(context.Background(), feed, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.calls)
+}
+
+func TestGetRounds(t *testing.T) {
+	store := newTestStore(t, 10, 7, 4)
+	store.insert(37)
+	feed := NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+
+	rounds, err := GetRounds(context.Background(), feed, 1, 100)
+	require.NoError(t, err)
+	var ids []uint32
+	for _, r := range rounds {
+		ids = append(ids, r.RoundID)
+		assert.Equal(t, uint64(r.RoundID), r.Slot)
+	}
+	assert.Equal(t, []uint32{12, 16, 20, 24, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37}, ids)
+
+	// header, one slice per buffer and the header check
+	store.calls = 0
+	_, err = GetRounds(context.Background(), feed, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 4, store.calls)
+
+	// historical buffer not filled yet
+	store = newTestStore(t, 4, 10, 2)
+	store.insert(9)
+	rounds, err = GetRounds(context.Background(), NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed), 1, 9)
+	require.NoError(t, err)
+	ids = nil
+	for _, r := range rounds {
+		ids = append(ids, r.RoundID)
+	}
+	assert.Equal(t, []uint32{2, 4, 6, 7, 8, 9}, ids)
+
+	_, err = GetRounds(context.Background(), feed, 2, 1)
+	assert.Error(t, err)
+}
+
+func TestGetRounds_Overwritten(t *testing.T) {
+	store := newTestStore(t, 4, 4, 2)
+	store.insert(10)
+	feed := NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	_, err := GetRound(context.Background(), feed, 7) // probe historical length
+	require.NoError(t, err)
+
+	// round 7 leaves the live buffer while it is read, it is read again from the historical buffer
+	store.calls = 0
+	store.onSlice = func(calls int) {
+		if calls == 2 {
+			store.insert(2)
+		}
+	}
+	round, err := GetRound(context.Background(), feed, 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(6), round.RoundID)
+
+	// feed updating faster than reads, the whole live buffer is overwritten during each read
+	store.onSlice = func(int) { store.insert(4) }
+	_, err = GetRounds(context.Background(), feed, 1, math.MaxUint32)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "overwritten")
+}
+
+func TestGetFeedHeader(t *testing.T) {
+	store := newTestStore(t, 4, 4, 2)
+	store.insert(5)
+	store.header.State = uint8(FeedStateFlagged)
+	store.header.Owner = solana.NewWallet().PublicKey()
+	store.header.Writer = solana.NewWallet().PublicKey()
+	copy(store.header.Description[:], "ETH / USD")
+	store.header.Decimals = 8
+	store.header.FlaggingThreshold = 1000
+
+	header, _, err := GetFeedHeader(context.Background(), store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, FeedHeader{
+		Version:           FeedVersion,
+		State:             FeedStateFlagged,
+		Owner:             store.header.Owner,
+		Writer:            store.header.Writer,
+		Description:       "ETH / USD",
+		Decimals:          8,
+		FlaggingThreshold: 1000,
+		LatestRoundID:     5,
+		Granularity:       2,
+		LiveLength:        4,
+		LiveCursor:        1,
+		HistoricalCursor:  2,
+	}, header)
+	assert.Equal(t, "FLAGGED", header.State.String())
+
+	// unsupported version
+	store.header.Version = 3
+	_, _, err = GetFeedHeader(context.Background(), store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+}
+
+func TestNewFeedHeader(t *testing.T) {
+	var h TransmissionsHeader
+	copy(h.Description[:], "BTC\xff/USD")
+	assert.Equal(t, "BTC�/USD", NewFeedHeader(h).Description)
+	assert.Equal(t, "NORMAL", NewFeedHeader(h).State.String())
+	h.State = 7
+	assert.Equal(t, "UNKNOWN(7)", NewFeedHeader(h).State.String())
+}
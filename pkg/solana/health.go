@@ -0,0 +1,50 @@
+package solana
+
+import (
+	"sort"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// healthReport collects degraded conditions observed by the tracker, keyed by source.
+// Conditions do not stop polling or transmitting, they are surfaced through Healthy.
+type healthReport struct {
+	lock   sync.RWMutex
+	issues map[string]error
+}
+
+func newHealthReport() *healthReport {
+	return &healthReport{issues: map[string]error{}}
+}
+
+// Set records a degraded condition for the given key, a nil error clears it.
+// It reports whether the key changed from healthy to degraded or back.
+func (h *healthReport) Set(key string, err error) (changed bool) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	_, degraded := h.issues[key]
+	if err == nil {
+		delete(h.issues, key)
+		return degraded
+	}
+	h.issues[key] = err
+	return !degraded
+}
+
+// Err combines all current degraded conditions, ordered by key
+func (h *healthReport) Err() error {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	keys := make([]string, 0, len(h.issues))
+	for k := range h.issues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var err error
+	for _, k := range keys {
+		err = multierr.Append(err, h.issues[k])
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package solana
+
+import (
+	"context"
+	"fmt"
+)
+
+// latestTransmissionWindow is the number of live buffer entries read from the previous latest transmission,
+// feeds receiving more transmissions between two reads fall back to reading the header
+const latestTransmissionWindow uint32 = 8
+
+// latestLocation is where the latest transmission was found in the live buffer
+type latestLocation struct {
+	index      uint32
+	slot       uint64
+	liveLength uint32
+	version    uint8
+}
+
+// LatestTransmission returns the latest transmission and the slot it was read at.
+//
+// Transmissions are written in slot order, so the cursor of the live buffer is the only entry with a lower slot than
+// the entry before it. The entries following the previous latest transmission are read with a single data slice and
+// the latest transmission is the one before that descent. The header and the entry before its cursor are read instead
+// when there is no previous location, or the descent is not within the window, e.g. when the cursor wrapped around.
+func (f *Feed) LatestTransmission(ctx context.Context) (Answer, uint64, error) {
+	f.latestLock.Lock()
+	defer f.latestLock.Unlock()
+
+	// a window starting at the end of the live buffer can't hold the descent
+	if f.latest != nil && f.latest.index+1 < f.latest.liveLength {
+		t, slot, ok, err := f.predictLatest(ctx, *f.latest)
+		if err != nil {
+			return Answer{}, 0, err
+		}
+		if ok {
+			return Answer{Data: t.Answer.BigInt(), Timestamp: t.Timestamp}, slot, nil
+		}
+	}
+
+	header, err := f.header(ctx)
+	if err != nil {
+		return Answer{}, 0, err
+	}
+	layout, err := feedLayout(header.Version)
+	if err != nil {
+		return Answer{}, 0, err
+	}
+	if header.LiveLength == 0 {
+		return Answer{}, 0, fmt.Errorf("transmissions account %s has no live buffer", f.account)
+	}
+	cursor := header.LiveCursor
+	if cursor == 0 { // handle array wrap
+		cursor = header.LiveLength
+	}
+	cursor-- // cursor indicates index for new answer, latest answer is in previous index
+
+	offset := AccountDiscriminatorLen + TransmissionsHeaderMaxSize + uint64(cursor)*layout.TransmissionLen
+	data, slot, err := f.slice(ctx, offset, layout.TransmissionLen)
+	if err != nil {
+		return Answer{}, 0, err
+	}
+	t, err := layout.DecodeTransmission(data)
+	if err != nil {
+		return Answer{}, 0, err
+	}
+
+	// transmissions without a slot (v1 layout) can't be located by descent
+	f.latest = nil
+	if t.Slot != 0 {
+		f.latest = &latestLocation{index: cursor, slot: t.Slot, liveLength: header.LiveLength, version: header.Version}
+	}
+	return Answer{Data: t.Answer.BigInt(), Timestamp: t.Timestamp}, slot, nil
+}
+
+// predictLatest looks up the latest transmission in the window following the previous latest transmission
+func (f *Feed) predictLatest(ctx context.Context, prev latestLocation) (Transmission, uint64, bool, error) {
+	layout, err := feedLayout(prev.version)
+	if err != nil {
+		return Transmission{}, 0, false, err
+	}
+	end := prev.index + latestTransmissionWindow
+	if end > prev.liveLength {
+		end = prev.liveLength // the window does not wrap around, the header is read once the cursor wraps
+	}
+	n := uint64(end - prev.index)
+	offset := AccountDiscriminatorLen + TransmissionsHeaderMaxSize + uint64(prev.index)*layout.TransmissionLen
+	data, slot, err := f.slice(ctx, offset, n*layout.TransmissionLen)
+	if err != nil {
+		return Transmission{}, 0, false, err
+	}
+	if uint64(len(data)) < n*layout.TransmissionLen {
+		return Transmission{}, 0, false, nil
+	}
+
+	var latest Transmission
+	for i := uint64(0); i < n; i++ {
+		t, err := layout.DecodeTransmission(data[i*layout.TransmissionLen : (i+1)*layout.TransmissionLen])
+		if err != nil {
+			return Transmission{}, 0, false, err
+		}
+		if i > 0 && t.Slot < latest.Slot {
+			// descent at the cursor, transmissions are never older than the previous latest one
+			if latest.Slot < prev.slot {
+				return Transmission{}, 0, false, nil
+			}
+			f.latest = &latestLocation{index: prev.index + uint32(i) - 1, slot: latest.Slot, liveLength: prev.liveLength, version: prev.version}
+			return latest, slot, true, nil
+		}
+		latest = t
+	}
+	return Transmission{}, 0, false, nil
+}
@@ -0,0 +1,62 @@
+package solana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_LatestTransmission(t *testing.T) {
+	store := newTestStore(t, 4, 3, 2)
+	feed := NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	latest := func(expectedRound uint32, expectedCalls int) {
+		store.calls = 0
+		answer, _, err := feed.LatestTransmission(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, expectedRound, answer.Timestamp)
+		assert.Equal(t, int64(expectedRound), answer.Data.Int64())
+		assert.Equal(t, expectedCalls, store.calls, "round %d", expectedRound)
+	}
+
+	// header and transmission without a previous location
+	store.insert(1)
+	latest(1, 2)
+
+	// single read around the previous location
+	store.insert(2)
+	latest(3, 1)
+	latest(3, 1)
+
+	// the cursor wraps around: the header is read until the location wrapped
+	store.insert(1)
+	latest(4, 3)
+	store.insert(1)
+	latest(5, 2)
+	store.insert(1)
+	latest(6, 1)
+
+	// more than a full lap between reads
+	store.insert(9)
+	latest(15, 1)
+
+	// more transmissions than the window holds
+	store = newTestStore(t, 20, 3, 2)
+	feed = NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	store.insert(1)
+	latest(1, 2)
+	store.insert(int(latestTransmissionWindow) + 1)
+	latest(latestTransmissionWindow+2, 3)
+	latest(latestTransmissionWindow+2, 1)
+
+	// transmissions without slots are always read through the header
+	store = newTestStore(t, 4, 3, 2)
+	store.header.Version = 1
+	feed = NewFeed(store, store.program, solana.NewWallet().PublicKey(), rpc.CommitmentConfirmed)
+	store.insert(3)
+	latest(3, 2)
+	latest(3, 2)
+}
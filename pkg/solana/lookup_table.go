@@ -0,0 +1,168 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+// AddressLookupTableProgramID is the native address lookup table program
+var AddressLookupTableProgramID = solana.MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+
+const (
+	// LookupTableMetaLen = ProgramState discriminator (u32), DeactivationSlot, LastExtendedSlot, LastExtendedSlotStartIndex, Authority (Option<Pubkey>), Padding
+	LookupTableMetaLen uint64 = 4 + 8 + 8 + 1 + 1 + 32 + 2
+
+	// address lookup table program instruction discriminators (bincode u32 enum)
+	lookupTableInstructionCreate uint32 = 0
+	lookupTableInstructionExtend uint32 = 2
+
+	lookupTableStateInitialized uint32 = 1
+)
+
+// LookupTable is a decoded address lookup table account
+type LookupTable struct {
+	Key              solana.PublicKey
+	DeactivationSlot uint64
+	Authority        *solana.PublicKey // nil if frozen
+	Addresses        []solana.PublicKey
+}
+
+func (t LookupTable) indexOf(key solana.PublicKey) (uint8, bool) {
+	for i, a := range t.Addresses {
+		if i > 255 {
+			break
+		}
+		if a.Equals(key) {
+			return uint8(i), true
+		}
+	}
+	return 0, false
+}
+
+// DecodeLookupTable decodes the raw data of an address lookup table account
+func DecodeLookupTable(key solana.PublicKey, data []byte) (LookupTable, error) {
+	if uint64(len(data)) < LookupTableMetaLen {
+		return LookupTable{}, fmt.Errorf("lookup table account too short: %d bytes", len(data))
+	}
+	if state := binary.LittleEndian.Uint32(data[0:4]); state != lookupTableStateInitialized {
+		return LookupTable{}, fmt.Errorf("lookup table account not initialized (state %d)", state)
+	}
+	if (uint64(len(data))-LookupTableMetaLen)%solana.PublicKeyLength != 0 {
+		return LookupTable{}, fmt.Errorf("lookup table account has invalid address data length: %d bytes", uint64(len(data))-LookupTableMetaLen)
+	}
+
+	t := LookupTable{
+		Key:              key,
+		DeactivationSlot: binary.LittleEndian.Uint64(data[4:12]),
+	}
+	if data[21] == 1 {
+		authority := solana.PublicKeyFromBytes(data[22:54])
+		t.Authority = &authority
+	}
+	for offset := LookupTableMetaLen; offset < uint64(len(data)); offset += solana.PublicKeyLength {
+		t.Addresses = append(t.Addresses, solana.PublicKeyFromBytes(data[offset:offset+solana.PublicKeyLength]))
+	}
+	return t, nil
+}
+
+// GetLookupTable fetches and decodes an address lookup table account
+func GetLookupTable(ctx context.Context, reader client.AccountReader, account solana.PublicKey, commitment rpc.CommitmentType) (LookupTable, error) {
+	res, err := reader.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Commitment: commitment,
+		Encoding:   "base64",
+	})
+	if err != nil {
+		return LookupTable{}, fmt.Errorf("failed to fetch lookup table account at address '%s': %w", account.String(), err)
+	}
+
+	// check for nil pointers
+	if res == nil || res.Value == nil || res.Value.Data == nil {
+		return LookupTable{}, errors.New("nil pointer returned in GetLookupTable.GetAccountInfoWithOpts")
+	}
+	if !res.Value.Owner.Equals(AddressLookupTableProgramID) {
+		return LookupTable{}, fmt.Errorf("account %s is not owned by the address lookup table program (owner %s)", account, res.Value.Owner)
+	}
+	return DecodeLookupTable(account, res.Value.Data.GetBinary())
+}
+
+// FindLookupTableAddress derives the address of the lookup table created by authority at recentSlot
+func FindLookupTableAddress(authority solana.PublicKey, recentSlot uint64) (solana.PublicKey, uint8, error) {
+	slot := make([]byte, 8)
+	binary.LittleEndian.PutUint64(slot, recentSlot)
+	return solana.FindProgramAddress([][]byte{authority.Bytes(), slot}, AddressLookupTableProgramID)
+}
+
+// NewCreateLookupTableInstruction creates an address lookup table owned by authority, recentSlot must be a recent slot
+func NewCreateLookupTableInstruction(authority, payer solana.PublicKey, recentSlot uint64) (solana.Instruction, solana.PublicKey, error) {
+	table, bump, err := FindLookupTableAddress(authority, recentSlot)
+	if err != nil {
+		return nil, solana.PublicKey{}, errors.Wrap(err, "error in NewCreateLookupTableInstruction.FindLookupTableAddress")
+	}
+
+	data := make([]byte, 4+8+1)
+	binary.LittleEndian.PutUint32(data[0:4], lookupTableInstructionCreate)
+	binary.LittleEndian.PutUint64(data[4:12], recentSlot)
+	data[12] = bump
+
+	return solana.NewInstruction(AddressLookupTableProgramID, []*solana.AccountMeta{
+		{PublicKey: table, IsWritable: true},
+		{PublicKey: authority, IsSigner: true},
+		{PublicKey: payer, IsSigner: true, IsWritable: true},
+		{PublicKey: solana.SystemProgramID},
+	}, data), table, nil
+}
+
+// NewExtendLookupTableInstruction appends addresses to an address lookup table
+func NewExtendLookupTableInstruction(table, authority, payer solana.PublicKey, addresses []solana.PublicKey) solana.Instruction {
+	data := make([]byte, 4+8, 4+8+len(addresses)*solana.PublicKeyLength)
+	binary.LittleEndian.PutUint32(data[0:4], lookupTableInstructionExtend)
+	binary.LittleEndian.PutUint64(data[4:12], uint64(len(addresses))) // bincode vec length
+	for _, a := range addresses {
+		data = append(data, a[:]...)
+	}
+
+	return solana.NewInstruction(AddressLookupTableProgramID, []*solana.AccountMeta{
+		{PublicKey: table, IsWritable: true},
+		{PublicKey: authority, IsSigner: true},
+		{PublicKey: payer, IsSigner: true, IsWritable: true},
+		{PublicKey: solana.SystemProgramID},
+	}, data)
+}
+
+// FeedLookupTableAddresses returns the feed accounts stored in its lookup table:
+// state, transmissions, store program and store authority
+func FeedLookupTableAddresses(programID, stateID, transmissionsID, storeProgramID solana.PublicKey) ([]solana.PublicKey, error) {
+	storeAuthority, _, err := FindStoreAuthority(programID, stateID)
+	if err != nil {
+		return nil, err
+	}
+	return []solana.PublicKey{stateID, transmissionsID, storeProgramID, storeAuthority}, nil
+}
+
+// NewFeedLookupTableInstructions returns the instructions creating the lookup table for a feed and extending it with
+// the feed accounts. Lookup tables can be used one slot after they are extended.
+func NewFeedLookupTableInstructions(
+	authority, payer solana.PublicKey,
+	recentSlot uint64,
+	programID, stateID, transmissionsID, storeProgramID solana.PublicKey,
+) (solana.PublicKey, []solana.Instruction, error) {
+	addresses, err := FeedLookupTableAddresses(programID, stateID, transmissionsID, storeProgramID)
+	if err != nil {
+		return solana.PublicKey{}, nil, err
+	}
+	create, table, err := NewCreateLookupTableInstruction(authority, payer, recentSlot)
+	if err != nil {
+		return solana.PublicKey{}, nil, err
+	}
+	return table, []solana.Instruction{
+		create,
+		NewExtendLookupTableInstruction(table, authority, payer, addresses),
+	}, nil
+}
@@ -0,0 +1,132 @@
+package solana
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeLookupTable returns the raw account data of an active lookup table
+func encodeLookupTable(authority solana.PublicKey, addresses []solana.PublicKey) []byte {
+	data := make([]byte, LookupTableMetaLen)
+	binary.LittleEndian.PutUint32(data[0:4], lookupTableStateInitialized)
+	binary.LittleEndian.PutUint64(data[4:12], math.MaxUint64)
+	data[21] = 1
+	copy(data[22:54], authority[:])
+	for _, a := range addresses {
+		data = append(data, a[:]...)
+	}
+	return data
+}
+
+func TestDecodeLookupTable(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	addresses := []solana.PublicKey{solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()}
+	key := solana.NewWallet().PublicKey()
+
+	table, err := DecodeLookupTable(key, encodeLookupTable(authority, addresses))
+	require.NoError(t, err)
+	assert.Equal(t, key, table.Key)
+	assert.Equal(t, uint64(math.MaxUint64), table.DeactivationSlot)
+	require.NotNil(t, table.Authority)
+	assert.Equal(t, authority, *table.Authority)
+	assert.Equal(t, addresses, table.Addresses)
+
+	i, ok := table.indexOf(addresses[1])
+	assert.True(t, ok)
+	assert.Equal(t, uint8(1), i)
+
+	// invalid data
+	_, err = DecodeLookupTable(key, make([]byte, LookupTableMetaLen-1))
+	assert.Error(t, err)
+	_, err = DecodeLookupTable(key, make([]byte, LookupTableMetaLen))
+	assert.Error(t, err)
+	_, err = DecodeLookupTable(key, append(encodeLookupTable(authority, addresses), 1))
+	assert.Error(t, err)
+}
+
+func TestNewFeedLookupTableInstructions(t *testing.T) {
+	authority := solana.NewWallet().PublicKey()
+	programID, stateID, transmissionsID, storeProgramID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	table, ixs, err := NewFeedLookupTableInstructions(authority, authority, 42, programID, stateID, transmissionsID, storeProgramID)
+	require.NoError(t, err)
+	require.Len(t, ixs, 2)
+
+	expectedTable, bump, err := FindLookupTableAddress(authority, 42)
+	require.NoError(t, err)
+	assert.Equal(t, expectedTable, table)
+
+	create, err := ixs[0].Data()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 42, 0, 0, 0, 0, 0, 0, 0, bump}, create)
+	assert.Equal(t, table, ixs[0].Accounts()[0].PublicKey)
+
+	extend, err := ixs[1].Data()
+	require.NoError(t, err)
+	require.Len(t, extend, 4+8+4*32)
+	assert.Equal(t, []byte{2, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0}, extend[:12])
+	storeAuthority, _, err := FindStoreAuthority(programID, stateID)
+	require.NoError(t, err)
+	assert.Equal(t, storeAuthority[:], extend[12+3*32:])
+}
+
+func TestNewMessageV0(t *testing.T) {
+	programID, stateID, transmissionsID, storeProgramID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	transmitter := solana.NewWallet().PublicKey()
+	storeAuthority, nonce, err := FindStoreAuthority(programID, stateID)
+	require.NoError(t, err)
+	addresses, err := FeedLookupTableAddresses(programID, stateID, transmissionsID, storeProgramID)
+	require.NoError(t, err)
+	table := LookupTable{Key: solana.NewWallet().PublicKey(), Addresses: addresses}
+
+	sigs := make([]types.AttributedOnchainSignature, 7)
+	for i := range sigs {
+		sigs[i].Signature = make([]byte, SignatureLen)
+	}
+	ix := newTransmitInstruction(programID, stateID, transmitter, transmissionsID, storeProgramID, storeAuthority, nonce, types.ReportContext{}, make(types.Report, ReportLen), sigs)
+
+	msg, err := NewMessageV0([]solana.Instruction{ix}, solana.Hash{1}, transmitter, []LookupTable{table})
+	require.NoError(t, err)
+	assert.Equal(t, []solana.PublicKey{transmitter, programID}, msg.StaticAccountKeys)
+	assert.Equal(t, solana.MessageHeader{NumRequiredSignatures: 1, NumReadonlySignedAccounts: 0, NumReadonlyUnsignedAccounts: 1}, msg.Header)
+	require.Len(t, msg.AddressTableLookups, 1)
+	assert.Equal(t, []uint8{0, 1}, msg.AddressTableLookups[0].WritableIndexes)
+	assert.Equal(t, []uint8{2, 3}, msg.AddressTableLookups[0].ReadonlyIndexes)
+
+	// accounts resolve against static keys, then loaded writable, then loaded readonly
+	require.Len(t, msg.Instructions, 1)
+	assert.Equal(t, uint16(1), msg.Instructions[0].ProgramIDIndex)
+	assert.Equal(t, []uint16{2, 0, 3, 4, 5}, msg.Instructions[0].Accounts)
+
+	raw, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, messageVersionPrefix, raw[0])
+	var decoded MessageV0
+	require.NoError(t, decoded.UnmarshalBinary(raw))
+	assert.Equal(t, msg, decoded)
+	assert.Error(t, decoded.UnmarshalBinary(append(raw, 0)))
+	assert.Error(t, decoded.UnmarshalBinary(raw[:len(raw)-1]))
+	size, err := msg.TxSize()
+	require.NoError(t, err)
+	assert.Equal(t, 1+64+len(raw), size)
+
+	// v0 message is smaller than the legacy message for the same instruction
+	legacy, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{1}, solana.TransactionPayer(transmitter))
+	require.NoError(t, err)
+	legacySize, err := TxSize(legacy.Message)
+	require.NoError(t, err)
+	assert.Less(t, size, legacySize)
+
+	// without lookup tables all accounts are static
+	msg, err = NewMessageV0([]solana.Instruction{ix}, solana.Hash{1}, transmitter, nil)
+	require.NoError(t, err)
+	assert.Len(t, msg.StaticAccountKeys, 6)
+	assert.Empty(t, msg.AddressTableLookups)
+	assert.Equal(t, legacy.Message.Header, msg.Header)
+}
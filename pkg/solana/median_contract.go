@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
 )
 
@@ -56,5 +57,15 @@ func (c *ContractTracker) LatestRoundRequested(
 	err error,
 ) {
 	state, err := c.ReadState()
-	return state.Config.LatestConfigDigest, 0, 0, err
+	if err != nil {
+		return configDigest, epoch, round, errors.Wrap(err, "error in LatestRoundRequested.ReadState")
+	}
+	rr, err := c.roundRequests.Latest(ctx, state, lookback, c.cfg.OCR2CachePollPeriod())
+	if err != nil {
+		return configDigest, epoch, round, errors.Wrap(err, "error in LatestRoundRequested")
+	}
+	if rr == nil {
+		return configDigest, epoch, round, nil
+	}
+	return rr.ConfigDigest, rr.Epoch, rr.Round, nil
 }
@@ -0,0 +1,372 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+// messageVersionPrefix marks a versioned message, the lower 7 bits carry the version (0)
+const messageVersionPrefix byte = 0x80
+
+// MessageAddressTableLookup references accounts loaded from an address lookup table
+type MessageAddressTableLookup struct {
+	AccountKey      solana.PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// MessageV0 is a version 0 transaction message supporting address lookup tables.
+// The pinned solana-go release only supports legacy messages.
+type MessageV0 struct {
+	Header              solana.MessageHeader
+	StaticAccountKeys   []solana.PublicKey
+	RecentBlockhash     solana.Hash
+	Instructions        []solana.CompiledInstruction
+	AddressTableLookups []MessageAddressTableLookup
+}
+
+type compiledKey struct {
+	key        solana.PublicKey
+	isSigner   bool
+	isWritable bool
+	isInvoked  bool
+}
+
+// NewMessageV0 compiles instructions into a v0 message, loading every account found in the lookup tables
+// from the table except for the payer, signers and invoked programs which must be static keys.
+func NewMessageV0(instructions []solana.Instruction, blockhash solana.Hash, payer solana.PublicKey, tables []LookupTable) (MessageV0, error) {
+	// collect unique accounts, payer first
+	keys := []*compiledKey{{key: payer, isSigner: true, isWritable: true}}
+	index := map[solana.PublicKey]*compiledKey{payer: keys[0]}
+	add := func(k compiledKey) {
+		if existing, ok := index[k.key]; ok {
+			existing.isSigner = existing.isSigner || k.isSigner
+			existing.isWritable = existing.isWritable || k.isWritable
+			existing.isInvoked = existing.isInvoked || k.isInvoked
+			return
+		}
+		keys = append(keys, &k)
+		index[k.key] = &k
+	}
+	for _, ix := range instructions {
+		for _, acc := range ix.Accounts() {
+			add(compiledKey{key: acc.PublicKey, isSigner: acc.IsSigner, isWritable: acc.IsWritable})
+		}
+		add(compiledKey{key: ix.ProgramID(), isInvoked: true})
+	}
+
+	// split static and loaded accounts
+	var static []*compiledKey
+	var lookups []MessageAddressTableLookup
+	var loadedWritable, loadedReadonly []solana.PublicKey
+	loaded := map[solana.PublicKey]bool{}
+	for _, t := range tables {
+		lookup := MessageAddressTableLookup{AccountKey: t.Key}
+		for _, k := range keys {
+			if k.isSigner || k.isInvoked || loaded[k.key] {
+				continue
+			}
+			i, ok := t.indexOf(k.key)
+			if !ok {
+				continue
+			}
+			loaded[k.key] = true
+			if k.isWritable {
+				lookup.WritableIndexes = append(lookup.WritableIndexes, i)
+				loadedWritable = append(loadedWritable, k.key)
+			} else {
+				lookup.ReadonlyIndexes = append(lookup.ReadonlyIndexes, i)
+				loadedReadonly = append(loadedReadonly, k.key)
+			}
+		}
+		if len(lookup.WritableIndexes)+len(lookup.ReadonlyIndexes) > 0 {
+			lookups = append(lookups, lookup)
+		}
+	}
+	for _, k := range keys {
+		if !loaded[k.key] {
+			static = append(static, k)
+		}
+	}
+
+	// static keys ordering: writable signers, readonly signers, writable non-signers, readonly non-signers
+	rank := func(k *compiledKey) int {
+		switch {
+		case k.isSigner && k.isWritable:
+			return 0
+		case k.isSigner:
+			return 1
+		case k.isWritable:
+			return 2
+		default:
+			return 3
+		}
+	}
+	sort.SliceStable(static, func(i, j int) bool { return rank(static[i]) < rank(static[j]) })
+
+	msg := MessageV0{
+		RecentBlockhash:     blockhash,
+		AddressTableLookups: lookups,
+	}
+	for _, k := range static {
+		msg.StaticAccountKeys = append(msg.StaticAccountKeys, k.key)
+		switch rank(k) {
+		case 0:
+			msg.Header.NumRequiredSignatures++
+		case 1:
+			msg.Header.NumRequiredSignatures++
+			msg.Header.NumReadonlySignedAccounts++
+		case 3:
+			msg.Header.NumReadonlyUnsignedAccounts++
+		}
+	}
+
+	// resolve instruction account indexes against static + loaded writable + loaded readonly keys
+	all := append(append(append([]solana.PublicKey{}, msg.StaticAccountKeys...), loadedWritable...), loadedReadonly...)
+	if len(all) > 256 {
+		return MessageV0{}, fmt.Errorf("too many accounts in message: %d", len(all))
+	}
+	position := map[solana.PublicKey]uint16{}
+	for i, k := range all {
+		position[k] = uint16(i)
+	}
+	for _, ix := range instructions {
+		data, err := ix.Data()
+		if err != nil {
+			return MessageV0{}, errors.Wrap(err, "error in NewMessageV0.Data")
+		}
+		compiled := solana.CompiledInstruction{
+			ProgramIDIndex: position[ix.ProgramID()],
+			Data:           data,
+		}
+		for _, acc := range ix.Accounts() {
+			compiled.Accounts = append(compiled.Accounts, position[acc.PublicKey])
+		}
+		msg.Instructions = append(msg.Instructions, compiled)
+	}
+	return msg, nil
+}
+
+// MarshalBinary serializes the message, this is the payload signed by the signers
+func (m MessageV0) MarshalBinary() ([]byte, error) {
+	buf := []byte{
+		messageVersionPrefix,
+		m.Header.NumRequiredSignatures,
+		m.Header.NumReadonlySignedAccounts,
+		m.Header.NumReadonlyUnsignedAccounts,
+	}
+
+	bin.EncodeCompactU16Length(&buf, len(m.StaticAccountKeys))
+	for _, key := range m.StaticAccountKeys {
+		buf = append(buf, key[:]...)
+	}
+
+	buf = append(buf, m.RecentBlockhash[:]...)
+
+	bin.EncodeCompactU16Length(&buf, len(m.Instructions))
+	for _, ix := range m.Instructions {
+		buf = append(buf, byte(ix.ProgramIDIndex))
+		bin.EncodeCompactU16Length(&buf, len(ix.Accounts))
+		for _, i := range ix.Accounts {
+			buf = append(buf, byte(i))
+		}
+		bin.EncodeCompactU16Length(&buf, len(ix.Data))
+		buf = append(buf, ix.Data...)
+	}
+
+	bin.EncodeCompactU16Length(&buf, len(m.AddressTableLookups))
+	for _, l := range m.AddressTableLookups {
+		buf = append(buf, l.AccountKey[:]...)
+		bin.EncodeCompactU16Length(&buf, len(l.WritableIndexes))
+		buf = append(buf, l.WritableIndexes...)
+		bin.EncodeCompactU16Length(&buf, len(l.ReadonlyIndexes))
+		buf = append(buf, l.ReadonlyIndexes...)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a serialized v0 message
+func (m *MessageV0) UnmarshalBinary(data []byte) error {
+	dec := bin.NewBinDecoder(data)
+	raw, err := dec.ReadNBytes(4)
+	if err != nil {
+		return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.header")
+	}
+	if raw[0] != messageVersionPrefix {
+		return fmt.Errorf("unsupported message version prefix: %#x", raw[0])
+	}
+	m.Header = solana.MessageHeader{
+		NumRequiredSignatures:       raw[1],
+		NumReadonlySignedAccounts:   raw[2],
+		NumReadonlyUnsignedAccounts: raw[3],
+	}
+
+	// readBytes reads a compact-u16 prefixed byte array
+	readBytes := func() ([]byte, error) {
+		n, err := dec.ReadCompactU16Length()
+		if err != nil {
+			return nil, err
+		}
+		return dec.ReadNBytes(n)
+	}
+
+	n, err := dec.ReadCompactU16Length()
+	if err != nil {
+		return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.accountKeys")
+	}
+	m.StaticAccountKeys = make([]solana.PublicKey, n)
+	for i := range m.StaticAccountKeys {
+		if raw, err = dec.ReadNBytes(solana.PublicKeyLength); err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.accountKeys")
+		}
+		copy(m.StaticAccountKeys[i][:], raw)
+	}
+
+	if raw, err = dec.ReadNBytes(len(m.RecentBlockhash)); err != nil {
+		return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.recentBlockhash")
+	}
+	copy(m.RecentBlockhash[:], raw)
+
+	if n, err = dec.ReadCompactU16Length(); err != nil {
+		return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.instructions")
+	}
+	m.Instructions = make([]solana.CompiledInstruction, n)
+	for i := range m.Instructions {
+		programIDIndex, err := dec.ReadByte()
+		if err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.programIDIndex")
+		}
+		accounts, err := readBytes()
+		if err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.instructionAccounts")
+		}
+		ixData, err := readBytes()
+		if err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.instructionData")
+		}
+		m.Instructions[i] = solana.CompiledInstruction{ProgramIDIndex: uint16(programIDIndex), Data: ixData}
+		for _, a := range accounts {
+			m.Instructions[i].Accounts = append(m.Instructions[i].Accounts, uint16(a))
+		}
+	}
+
+	if n, err = dec.ReadCompactU16Length(); err != nil {
+		return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.addressTableLookups")
+	}
+	m.AddressTableLookups = make([]MessageAddressTableLookup, n)
+	for i := range m.AddressTableLookups {
+		l := &m.AddressTableLookups[i]
+		if raw, err = dec.ReadNBytes(solana.PublicKeyLength); err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.lookupTable")
+		}
+		copy(l.AccountKey[:], raw)
+		if l.WritableIndexes, err = readBytes(); err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.writableIndexes")
+		}
+		if l.ReadonlyIndexes, err = readBytes(); err != nil {
+			return errors.Wrap(err, "error in MessageV0.UnmarshalBinary.readonlyIndexes")
+		}
+	}
+	if dec.Remaining() > 0 {
+		return fmt.Errorf("%d trailing bytes after message", dec.Remaining())
+	}
+	return nil
+}
+
+// ResolveAccountKeys returns the accounts referenced by the instruction indexes:
+// static keys, followed by the writable and then the readonly keys loaded from every lookup table.
+// Lookup tables are append only, their current content resolves the indexes as long as the tables exist.
+func (m MessageV0) ResolveAccountKeys(ctx context.Context, reader client.AccountReader, commitment rpc.CommitmentType) ([]solana.PublicKey, error) {
+	keys := append([]solana.PublicKey{}, m.StaticAccountKeys...)
+	var readonly []solana.PublicKey
+	for _, l := range m.AddressTableLookups {
+		table, err := GetLookupTable(ctx, reader, l.AccountKey, commitment)
+		if err != nil {
+			return nil, err
+		}
+		for _, indexes := range []struct {
+			in  []uint8
+			out *[]solana.PublicKey
+		}{{l.WritableIndexes, &keys}, {l.ReadonlyIndexes, &readonly}} {
+			for _, i := range indexes.in {
+				if int(i) >= len(table.Addresses) {
+					return nil, fmt.Errorf("lookup table %s index %d out of range", l.AccountKey, i)
+				}
+				*indexes.out = append(*indexes.out, table.Addresses[i])
+			}
+		}
+	}
+	return append(keys, readonly...), nil
+}
+
+func (m MessageV0) ToBase64() string {
+	out, _ := m.MarshalBinary()
+	return base64.StdEncoding.EncodeToString(out)
+}
+
+// TxSize returns the serialized size of a transaction for the message once all required signatures are attached
+func (m MessageV0) TxSize() (int, error) {
+	raw, err := m.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n := int(m.Header.NumRequiredSignatures)
+	return compactU16Len(n) + n*solana.SignatureLength + len(raw), nil
+}
+
+// VersionedTransaction is a signed v0 transaction
+type VersionedTransaction struct {
+	Signatures []solana.Signature
+	Message    MessageV0
+}
+
+func (tx VersionedTransaction) MarshalBinary() ([]byte, error) {
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var buf []byte
+	bin.EncodeCompactU16Length(&buf, len(tx.Signatures))
+	for _, sig := range tx.Signatures {
+		buf = append(buf, sig[:]...)
+	}
+	return append(buf, msg...), nil
+}
+
+// UnmarshalBinary decodes a serialized v0 transaction
+func (tx *VersionedTransaction) UnmarshalBinary(data []byte) error {
+	dec := bin.NewBinDecoder(data)
+	n, err := dec.ReadCompactU16Length()
+	if err != nil {
+		return errors.Wrap(err, "error in VersionedTransaction.UnmarshalBinary.signatures")
+	}
+	tx.Signatures = make([]solana.Signature, n)
+	for i := range tx.Signatures {
+		raw, err := dec.ReadNBytes(solana.SignatureLength)
+		if err != nil {
+			return errors.Wrap(err, "error in VersionedTransaction.UnmarshalBinary.signatures")
+		}
+		copy(tx.Signatures[i][:], raw)
+	}
+	return tx.Message.UnmarshalBinary(data[len(data)-dec.Remaining():])
+}
+
+// IsVersionedTransaction reports whether a serialized transaction holds a versioned message
+func IsVersionedTransaction(data []byte) bool {
+	dec := bin.NewBinDecoder(data)
+	n, err := dec.ReadCompactU16Length()
+	if err != nil {
+		return false
+	}
+	offset := len(data) - dec.Remaining() + n*solana.SignatureLength
+	return offset < len(data) && data[offset]&messageVersionPrefix != 0
+}
@@ -0,0 +1,210 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/smartcontractkit/chainlink/core/services"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+var promSolanaBalance = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{Name: "solana_transmitter_balance", Help: "Solana transmitter account balances (SOL)"},
+	[]string{"account", "chainID"},
+)
+
+// Config defines the balance monitor configuration.
+type Config interface {
+	BalancePollPeriod() time.Duration
+	BalanceWarnThreshold() uint64     // lamports
+	BalanceCriticalThreshold() uint64 // lamports
+}
+
+var _ services.ServiceCtx = (*BalanceMonitor)(nil)
+
+// BalanceMonitor polls the SOL balance of every transmitter key in use on a chain
+type BalanceMonitor struct {
+	chainID   string
+	cfg       Config
+	lggr      logger.Logger
+	newReader func() (client.Reader, error)
+	updateFn  func(acc solana.PublicKey, lamports uint64) // overridable for testing
+
+	// tracked transmitter keys (reference counted) and their latest balances
+	keys     map[solana.PublicKey]int
+	balances map[solana.PublicKey]uint64
+	lock     sync.RWMutex
+
+	stop, done chan struct{}
+
+	utils.StartStopOnce
+}
+
+// NewBalanceMonitor returns a balance monitor for the given chain, newReader is called on every poll
+// so that a different node may be used each time.
+func NewBalanceMonitor(chainID string, cfg Config, lggr logger.Logger, newReader func() (client.Reader, error)) *BalanceMonitor {
+	b := &BalanceMonitor{
+		chainID:   chainID,
+		cfg:       cfg,
+		lggr:      lggr,
+		newReader: newReader,
+		keys:      map[solana.PublicKey]int{},
+		balances:  map[solana.PublicKey]uint64{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	b.updateFn = b.updateProm
+	return b
+}
+
+// Start polling
+func (b *BalanceMonitor) Start(context.Context) error {
+	return b.StartOnce("SolanaBalanceMonitor", func() error {
+		go b.monitor()
+		return nil
+	})
+}
+
+// Close stops the polling
+func (b *BalanceMonitor) Close() error {
+	return b.StopOnce("SolanaBalanceMonitor", func() error {
+		close(b.stop)
+		<-b.done
+		return nil
+	})
+}
+
+// Healthy returns an error if the monitor is not running or any tracked key is below BalanceCriticalThreshold
+func (b *BalanceMonitor) Healthy() error {
+	if err := b.StartStopOnce.Healthy(); err != nil {
+		return err
+	}
+	critical := b.cfg.BalanceCriticalThreshold()
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	var low []string
+	for k := range b.keys {
+		if balance, ok := b.balances[k]; ok && balance < critical {
+			low = append(low, fmt.Sprintf("%s (%d lamports)", k, balance))
+		}
+	}
+	if len(low) > 0 {
+		sort.Strings(low)
+		return fmt.Errorf("transmitter balance below critical threshold of %d lamports on chain %s: %v", critical, b.chainID, low)
+	}
+	return nil
+}
+
+// Track adds the key to the monitored set, the returned function removes it again.
+// Keys are reference counted so that multiple jobs may share the same transmitter.
+func (b *BalanceMonitor) Track(key solana.PublicKey) (untrack func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.keys[key]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.lock.Lock()
+			defer b.lock.Unlock()
+			b.keys[key]--
+			if b.keys[key] > 0 {
+				return
+			}
+			delete(b.keys, key)
+			delete(b.balances, key)
+			promSolanaBalance.DeleteLabelValues(key.String(), b.chainID)
+		})
+	}
+}
+
+// Balance returns the latest polled balance for key (lamports)
+func (b *BalanceMonitor) Balance(key solana.PublicKey) (uint64, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	balance, ok := b.balances[key]
+	return balance, ok
+}
+
+func (b *BalanceMonitor) monitor() {
+	defer close(b.done)
+	tick := time.After(0)
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-tick:
+			b.updateBalances()
+			tick = time.After(utils.WithJitter(b.cfg.BalancePollPeriod()))
+		}
+	}
+}
+
+func (b *BalanceMonitor) updateBalances() {
+	b.lock.RLock()
+	keys := make([]solana.PublicKey, 0, len(b.keys))
+	for k := range b.keys {
+		keys = append(keys, k)
+	}
+	b.lock.RUnlock()
+	if len(keys) == 0 {
+		return
+	}
+
+	reader, err := b.newReader()
+	if err != nil {
+		b.lggr.Errorf("failed to get client for balance monitor on chain %s: %s", b.chainID, err)
+		return
+	}
+
+	for _, k := range keys {
+		// Check for shutdown signal, since Balance blocks and may be slow.
+		select {
+		case <-b.stop:
+			return
+		default:
+		}
+		lamports, err := reader.Balance(k)
+		if err != nil {
+			b.lggr.Errorf("failed to get balance for %s on chain %s: %s", k, b.chainID, err)
+			continue
+		}
+
+		b.lock.Lock()
+		_, tracked := b.keys[k]
+		if tracked { // key may have been untracked while polling
+			b.balances[k] = lamports
+			b.updateFn(k, lamports)
+		}
+		b.lock.Unlock()
+		if tracked {
+			b.checkThresholds(k, lamports)
+		}
+	}
+}
+
+func (b *BalanceMonitor) checkThresholds(k solana.PublicKey, lamports uint64) {
+	sol := float64(lamports) / float64(solana.LAMPORTS_PER_SOL)
+	if critical := b.cfg.BalanceCriticalThreshold(); lamports < critical {
+		b.lggr.Criticalf("transmitter %s on chain %s has balance %.9f SOL below critical threshold of %d lamports", k, b.chainID, sol, critical)
+		return
+	}
+	if warn := b.cfg.BalanceWarnThreshold(); lamports < warn {
+		b.lggr.Warnf("transmitter %s on chain %s has balance %.9f SOL below warning threshold of %d lamports", k, b.chainID, sol, warn)
+	}
+}
+
+func (b *BalanceMonitor) updateProm(acc solana.PublicKey, lamports uint64) {
+	v := float64(lamports) / float64(solana.LAMPORTS_PER_SOL)
+	promSolanaBalance.WithLabelValues(acc.String(), b.chainID).Set(v)
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+)
+
+type testConfig struct{}
+
+func (testConfig) BalancePollPeriod() time.Duration { return 10 * time.Millisecond }
+func (testConfig) BalanceWarnThreshold() uint64     { return 1000 }
+func (testConfig) BalanceCriticalThreshold() uint64 { return 100 }
+
+func TestBalanceMonitor(t *testing.T) {
+	funded := solana.NewWallet().PublicKey()
+	low := solana.NewWallet().PublicKey()
+
+	reader := new(mocks.ReaderWriter)
+	reader.On("Balance", funded).Return(uint64(5000), nil)
+	reader.On("Balance", low).Return(uint64(50), nil)
+
+	b := NewBalanceMonitor("test-chain", testConfig{}, logger.TestLogger(t), func() (client.Reader, error) {
+		return reader, nil
+	})
+	var lock sync.Mutex
+	updates := map[solana.PublicKey]uint64{}
+	b.updateFn = func(acc solana.PublicKey, lamports uint64) {
+		lock.Lock()
+		defer lock.Unlock()
+		updates[acc] = lamports
+	}
+
+	// not started
+	assert.Error(t, b.Healthy())
+
+	untrackFunded := b.Track(funded)
+	require.NoError(t, b.Start(context.Background()))
+	defer func() { assert.NoError(t, b.Close()) }()
+
+	require.Eventually(t, func() bool {
+		_, ok := b.Balance(funded)
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	assert.NoError(t, b.Healthy())
+
+	// key below critical threshold marks the monitor unhealthy, shared keys are reference counted
+	untrackLow := b.Track(low)
+	untrackLowAgain := b.Track(low)
+	require.Eventually(t, func() bool {
+		return b.Healthy() != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, b.Healthy().Error(), low.String())
+
+	untrackLow()
+	untrackLow() // repeated calls are ignored
+	assert.Error(t, b.Healthy())
+	untrackLowAgain()
+	assert.NoError(t, b.Healthy())
+	_, ok := b.Balance(low)
+	assert.False(t, ok)
+
+	untrackFunded()
+	_, ok = b.Balance(funded)
+	assert.False(t, ok)
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, uint64(5000), updates[funded])
+	assert.Equal(t, uint64(50), updates[low])
+}
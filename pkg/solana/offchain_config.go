@@ -0,0 +1,370 @@
+package solana
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+)
+
+// OffchainConfigParams are the libocr protocol and median plugin parameters stored in the offchain config
+type OffchainConfigParams struct {
+	DeltaProgress time.Duration
+	DeltaResend   time.Duration
+	DeltaRound    time.Duration
+	DeltaGrace    time.Duration
+	DeltaStage    time.Duration
+	RMax          uint8
+	S             []int
+
+	MaxDurationQuery                        time.Duration
+	MaxDurationObservation                  time.Duration
+	MaxDurationReport                       time.Duration
+	MaxDurationShouldAcceptFinalizedReport  time.Duration
+	MaxDurationShouldTransmitAcceptedReport time.Duration
+
+	Median median.OffchainConfig
+}
+
+// DurationRange bounds a duration parameter, a zero Max is unbounded
+type DurationRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (r DurationRange) check(name string, d time.Duration) error {
+	if d < r.Min || (r.Max != 0 && d > r.Max) {
+		return fmt.Errorf("%s %s out of range [%s, %s]", name, d, r.Min, r.Max)
+	}
+	return nil
+}
+
+// OffchainConfigBounds are the accepted ranges of the offchain config timing parameters
+type OffchainConfigBounds struct {
+	DeltaProgress DurationRange
+	DeltaResend   DurationRange
+	DeltaRound    DurationRange
+	DeltaGrace    DurationRange
+	DeltaStage    DurationRange
+
+	MaxDurationQuery                        DurationRange
+	MaxDurationObservation                  DurationRange
+	MaxDurationReport                       DurationRange
+	MaxDurationShouldAcceptFinalizedReport  DurationRange
+	MaxDurationShouldTransmitAcceptedReport DurationRange
+
+	// median plugin heartbeat and deviation thresholds, a zero maximum threshold is unbounded
+	DeltaC            DurationRange
+	MaxAlphaReportPPB uint64
+	MaxAlphaAcceptPPB uint64
+}
+
+// DefaultOffchainConfigBounds are sane bounds for ~400ms solana slots:
+// rounds span several slots and a transmission has time to land before the next stage.
+// The bounds overridable by the chain config use its defaults.
+var DefaultOffchainConfigBounds = OffchainConfigBounds{
+	DeltaProgress: DurationRange{Min: config.DefaultOffchainConfigMinDeltaProgress, Max: config.DefaultOffchainConfigMaxDeltaProgress},
+	DeltaResend:   DurationRange{Min: time.Second, Max: 10 * time.Minute},
+	DeltaRound:    DurationRange{Min: config.DefaultOffchainConfigMinDeltaRound, Max: config.DefaultOffchainConfigMaxDeltaRound},
+	DeltaGrace:    DurationRange{Min: 100 * time.Millisecond, Max: time.Minute},
+	DeltaStage:    DurationRange{Min: time.Second, Max: 10 * time.Minute},
+
+	MaxDurationQuery:                        DurationRange{Max: config.DefaultOffchainConfigMaxDuration},
+	MaxDurationObservation:                  DurationRange{Min: 100 * time.Millisecond, Max: config.DefaultOffchainConfigMaxDuration},
+	MaxDurationReport:                       DurationRange{Min: 100 * time.Millisecond, Max: config.DefaultOffchainConfigMaxDuration},
+	MaxDurationShouldAcceptFinalizedReport:  DurationRange{Min: 100 * time.Millisecond, Max: config.DefaultOffchainConfigMaxDuration},
+	MaxDurationShouldTransmitAcceptedReport: DurationRange{Min: 100 * time.Millisecond, Max: config.DefaultOffchainConfigMaxDuration},
+
+	MaxAlphaReportPPB: config.DefaultOffchainConfigMaxAlphaPPB,
+	MaxAlphaAcceptPPB: config.DefaultOffchainConfigMaxAlphaPPB,
+}
+
+// resourceExhaustionInterval is the minimum DeltaProgress and DeltaResend libocr accepts,
+// more frequent messages could exhaust the oracle resources
+const resourceExhaustionInterval = 200 * time.Millisecond
+
+// maxSLen bounds the length of S, libocr rejects longer schedules to avoid overflows summing it
+const maxSLen = 1000
+
+// Validate checks the parameters of a config of n oracles tolerating f faulty ones: every rule libocr checks
+// when decoding the config, the relations it relies on and that every timing parameter is within bounds
+func (p OffchainConfigParams) Validate(bounds OffchainConfigBounds, n, f int) error {
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"DeltaProgress", p.DeltaProgress},
+		{"DeltaResend", p.DeltaResend},
+		{"DeltaRound", p.DeltaRound},
+		{"DeltaGrace", p.DeltaGrace},
+		{"DeltaStage", p.DeltaStage},
+		{"MaxDurationQuery", p.MaxDurationQuery},
+		{"MaxDurationObservation", p.MaxDurationObservation},
+		{"MaxDurationReport", p.MaxDurationReport},
+		{"MaxDurationShouldAcceptFinalizedReport", p.MaxDurationShouldAcceptFinalizedReport},
+		{"MaxDurationShouldTransmitAcceptedReport", p.MaxDurationShouldTransmitAcceptedReport},
+	} {
+		if d.value < 0 {
+			return fmt.Errorf("%s %s must not be negative", d.name, d.value)
+		}
+	}
+	if f < 0 || 3*f >= n {
+		return fmt.Errorf("F %d must be non-negative and less than N/3 (N = %d)", f, n)
+	}
+	if n > types.MaxOracles {
+		return fmt.Errorf("N %d exceeds %d oracles", n, types.MaxOracles)
+	}
+	if p.DeltaGrace >= p.DeltaRound {
+		return fmt.Errorf("DeltaGrace %s must be less than DeltaRound %s", p.DeltaGrace, p.DeltaRound)
+	}
+	if p.DeltaRound >= p.DeltaProgress {
+		return fmt.Errorf("DeltaRound %s must be less than DeltaProgress %s", p.DeltaRound, p.DeltaProgress)
+	}
+	if sum := p.MaxDurationQuery + p.MaxDurationObservation + p.MaxDurationReport; sum >= p.DeltaProgress {
+		return fmt.Errorf("sum of MaxDurationQuery/Observation/Report %s must be less than DeltaProgress %s", sum, p.DeltaProgress)
+	}
+	// libocr adds 1 to a round number that can equal RMax
+	if p.RMax == 0 || p.RMax == 255 {
+		return fmt.Errorf("RMax %d must be greater than zero and less than 255", p.RMax)
+	}
+	if len(p.S) >= maxSLen {
+		return fmt.Errorf("length of S %d must be less than %d", len(p.S), maxSLen)
+	}
+	for i, s := range p.S {
+		if s < 0 || s > types.MaxOracles {
+			return fmt.Errorf("S[%d] %d must be between 0 and %d", i, s, types.MaxOracles)
+		}
+	}
+	if p.DeltaProgress < resourceExhaustionInterval || p.DeltaResend < resourceExhaustionInterval {
+		return fmt.Errorf("DeltaProgress %s and DeltaResend %s must be at least %s", p.DeltaProgress, p.DeltaResend, resourceExhaustionInterval)
+	}
+
+	for _, c := range []struct {
+		name  string
+		r     DurationRange
+		value time.Duration
+	}{
+		{"DeltaProgress", bounds.DeltaProgress, p.DeltaProgress},
+		{"DeltaResend", bounds.DeltaResend, p.DeltaResend},
+		{"DeltaRound", bounds.DeltaRound, p.DeltaRound},
+		{"DeltaGrace", bounds.DeltaGrace, p.DeltaGrace},
+		{"DeltaStage", bounds.DeltaStage, p.DeltaStage},
+		{"MaxDurationQuery", bounds.MaxDurationQuery, p.MaxDurationQuery},
+		{"MaxDurationObservation", bounds.MaxDurationObservation, p.MaxDurationObservation},
+		{"MaxDurationReport", bounds.MaxDurationReport, p.MaxDurationReport},
+		{"MaxDurationShouldAcceptFinalizedReport", bounds.MaxDurationShouldAcceptFinalizedReport, p.MaxDurationShouldAcceptFinalizedReport},
+		{"MaxDurationShouldTransmitAcceptedReport", bounds.MaxDurationShouldTransmitAcceptedReport, p.MaxDurationShouldTransmitAcceptedReport},
+		{"DeltaC", bounds.DeltaC, p.Median.DeltaC},
+	} {
+		if err := c.r.check(c.name, c.value); err != nil {
+			return err
+		}
+	}
+
+	// infinite thresholds disable deviation based reports
+	if !p.Median.AlphaReportInfinite && bounds.MaxAlphaReportPPB != 0 && p.Median.AlphaReportPPB > bounds.MaxAlphaReportPPB {
+		return fmt.Errorf("AlphaReportPPB %d exceeds %d", p.Median.AlphaReportPPB, bounds.MaxAlphaReportPPB)
+	}
+	if !p.Median.AlphaAcceptInfinite && bounds.MaxAlphaAcceptPPB != 0 && p.Median.AlphaAcceptPPB > bounds.MaxAlphaAcceptPPB {
+		return fmt.Errorf("AlphaAcceptPPB %d exceeds %d", p.Median.AlphaAcceptPPB, bounds.MaxAlphaAcceptPPB)
+	}
+	return nil
+}
+
+// EncodeOffchainConfig validates params with the default bounds and encodes the offchain config for oracles.
+// Oracles must be sorted by onchain public key, the order the program stores them in.
+// The config is encoded by libocr, so the schema and the shared secret encryption always match the libocr version
+// the nodes decode it with. ContractSetConfigArgsForTests is the only encoder the pinned libocr exports.
+func EncodeOffchainConfig(params OffchainConfigParams, oracles []confighelper.OracleIdentityExtra, f int) (uint64, []byte, error) {
+	if err := params.Validate(DefaultOffchainConfigBounds, len(oracles), f); err != nil {
+		return 0, nil, errors.Wrap(err, "invalid offchain config")
+	}
+	signers, transmitters, f_, _, version, offchainConfig, err := confighelper.ContractSetConfigArgsForTests(
+		params.DeltaProgress,
+		params.DeltaResend,
+		params.DeltaRound,
+		params.DeltaGrace,
+		params.DeltaStage,
+		params.RMax,
+		params.S,
+		oracles,
+		params.Median.Encode(),
+		params.MaxDurationQuery,
+		params.MaxDurationObservation,
+		params.MaxDurationReport,
+		params.MaxDurationShouldAcceptFinalizedReport,
+		params.MaxDurationShouldTransmitAcceptedReport,
+		f,
+		nil, // onchain config is derived from the state min and max answer
+	)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "error in EncodeOffchainConfig.ContractSetConfigArgsForTests")
+	}
+	// decode as the nodes do, this also checks the oracle identities
+	if _, err = confighelper.PublicConfigFromContractConfig(false, types.ContractConfig{
+		Signers:               signers,
+		Transmitters:          transmitters,
+		F:                     f_,
+		OffchainConfigVersion: version,
+		OffchainConfig:        offchainConfig,
+	}); err != nil {
+		return 0, nil, errors.Wrap(err, "offchain config rejected by libocr")
+	}
+	if err = ValidateOffchainConfigLen(offchainConfig); err != nil {
+		return 0, nil, err
+	}
+	return version, offchainConfig, nil
+}
+
+// ValidateOffchainConfigLen checks the config can be written to a proposal,
+// write_offchain_config requires every write to be smaller than the remaining capacity
+func ValidateOffchainConfigLen(offchainConfig []byte) error {
+	if len(offchainConfig) == 0 || len(offchainConfig) >= MaxOffchainConfigLen {
+		return fmt.Errorf("offchain config length %d out of range (1..%d)", len(offchainConfig), MaxOffchainConfigLen-1)
+	}
+	return nil
+}
+
+// DecodeOffchainConfig decodes the offchain config of a contract config into readable parameters
+func DecodeOffchainConfig(cfg types.ContractConfig) (OffchainConfigParams, error) {
+	public, err := confighelper.PublicConfigFromContractConfig(true, cfg)
+	if err != nil {
+		return OffchainConfigParams{}, errors.Wrap(err, "error in DecodeOffchainConfig.PublicConfigFromContractConfig")
+	}
+	plugin, err := median.DecodeOffchainConfig(public.ReportingPluginConfig)
+	if err != nil {
+		return OffchainConfigParams{}, errors.Wrap(err, "error in DecodeOffchainConfig.DecodeOffchainConfig")
+	}
+	return OffchainConfigParams{
+		DeltaProgress:                           public.DeltaProgress,
+		DeltaResend:                             public.DeltaResend,
+		DeltaRound:                              public.DeltaRound,
+		DeltaGrace:                              public.DeltaGrace,
+		DeltaStage:                              public.DeltaStage,
+		RMax:                                    public.RMax,
+		S:                                       public.S,
+		MaxDurationQuery:                        public.MaxDurationQuery,
+		MaxDurationObservation:                  public.MaxDurationObservation,
+		MaxDurationReport:                       public.MaxDurationReport,
+		MaxDurationShouldAcceptFinalizedReport:  public.MaxDurationShouldAcceptFinalizedReport,
+		MaxDurationShouldTransmitAcceptedReport: public.MaxDurationShouldTransmitAcceptedReport,
+		Median:                                  plugin,
+	}, nil
+}
+
+// DecodeStateOffchainConfig decodes State.OffchainConfig into readable parameters
+func DecodeStateOffchainConfig(state State) (OffchainConfigParams, error) {
+	cfg, err := ConfigFromState(state)
+	if err != nil {
+		return OffchainConfigParams{}, err
+	}
+	return DecodeOffchainConfig(cfg)
+}
+
+// OffchainConfigChunkLen returns the largest offchain config chunk a write_offchain_config transaction can carry
+// when the payer and the proposal authority are distinct signers
+func OffchainConfigChunkLen() (int, error) {
+	// accounts are distinct placeholders, sizes do not depend on their values
+	keys := make([]solana.PublicKey, 4)
+	for i := range keys {
+		keys[i][0] = byte(i + 1)
+	}
+	ix, err := newWriteOffchainConfigInstruction(keys[0], keys[1], keys[2], nil)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{}, solana.TransactionPayer(keys[3]))
+	if err != nil {
+		return 0, errors.Wrap(err, "error in OffchainConfigChunkLen.NewTransaction")
+	}
+	size, err := TxSize(tx.Message)
+	if err != nil {
+		return 0, err
+	}
+	// the instruction data length prefix grows once the data exceeds 127 bytes
+	return MaxTxSize - size - (compactU16Len(MaxTxSize) - compactU16Len(0)), nil
+}
+
+// NewWriteOffchainConfigInstructions splits the offchain config into write_offchain_config instructions,
+// each fitting in its own transaction
+func NewWriteOffchainConfigInstructions(programID, proposal, authority solana.PublicKey, offchainConfig []byte) ([]solana.Instruction, error) {
+	chunkLen, err := OffchainConfigChunkLen()
+	if err != nil {
+		return nil, err
+	}
+	var instructions []solana.Instruction
+	for len(offchainConfig) > 0 {
+		chunk := offchainConfig
+		if len(chunk) > chunkLen {
+			chunk = chunk[:chunkLen]
+		}
+		ix, err := newWriteOffchainConfigInstruction(programID, proposal, authority, chunk)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, ix)
+		offchainConfig = offchainConfig[len(chunk):]
+	}
+	return instructions, nil
+}
+
+// newWriteOffchainConfigInstruction binds the generated instruction to programID
+func newWriteOffchainConfigInstruction(programID, proposal, authority solana.PublicKey, chunk []byte) (solana.Instruction, error) {
+	ix := ocr_2.NewWriteOffchainConfigInstruction(chunk, proposal, authority).Build()
+	data, err := ix.Data()
+	if err != nil {
+		return nil, errors.Wrap(err, "error in newWriteOffchainConfigInstruction.Data")
+	}
+	return solana.NewInstruction(programID, ix.Accounts(), data), nil
+}
+
+// healthKeyOffchainConfig identifies the offchain config sanity condition in the tracker health report
+const healthKeyOffchainConfig = "offchainConfig"
+
+// offchainConfigBounds returns the default bounds overridden by the chain config
+func (c *ContractTracker) offchainConfigBounds() OffchainConfigBounds {
+	bounds := DefaultOffchainConfigBounds
+	bounds.DeltaRound = DurationRange{Min: c.cfg.OffchainConfigMinDeltaRound(), Max: c.cfg.OffchainConfigMaxDeltaRound()}
+	bounds.DeltaProgress = DurationRange{Min: c.cfg.OffchainConfigMinDeltaProgress(), Max: c.cfg.OffchainConfigMaxDeltaProgress()}
+	for _, r := range []*DurationRange{
+		&bounds.MaxDurationQuery,
+		&bounds.MaxDurationObservation,
+		&bounds.MaxDurationReport,
+		&bounds.MaxDurationShouldAcceptFinalizedReport,
+		&bounds.MaxDurationShouldTransmitAcceptedReport,
+	} {
+		r.Max = c.cfg.OffchainConfigMaxDuration()
+	}
+	bounds.MaxAlphaReportPPB = c.cfg.OffchainConfigMaxAlphaPPB()
+	bounds.MaxAlphaAcceptPPB = c.cfg.OffchainConfigMaxAlphaPPB()
+	return bounds
+}
+
+// checkOffchainConfig decodes the offchain config of a newly seen config, logs its parameters
+// and reports an unhealthy condition if it can't be decoded or a value is out of bounds
+func (c *ContractTracker) checkOffchainConfig(state State) {
+	digest := types.ConfigDigest(state.Config.LatestConfigDigest)
+	params, err := DecodeStateOffchainConfig(state)
+	if err != nil {
+		err = fmt.Errorf("failed to decode offchain config for config %s: %w", digest, err)
+		c.lggr.Errorf("%s", err)
+		c.health.Set(healthKeyOffchainConfig, err)
+		return
+	}
+	c.lggr.Infof("offchain config for config %s: %+v", digest, params)
+
+	if err = params.Validate(c.offchainConfigBounds(), int(state.Oracles.Len), int(state.Config.F)); err != nil {
+		err = fmt.Errorf("offchain config for config %s out of bounds: %w", digest, err)
+		c.lggr.Warnf("%s", err)
+		c.health.Set(healthKeyOffchainConfig, err)
+		return
+	}
+	c.health.Set(healthKeyOffchainConfig, nil)
+}
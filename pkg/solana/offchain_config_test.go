@@ -0,0 +1,259 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/smartcontractkit/libocr/offchainreporting2/confighelper"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+)
+
+// testOracleIdentities returns n oracles sorted by signer and a state holding them
+func testOracleIdentities(t *testing.T, n int) ([]confighelper.OracleIdentityExtra, State) {
+	var state State
+	state.AccountDiscriminator = ocr_2.StateDiscriminator
+	state.Version = 1
+	state.Config.F = 1
+	var identities []confighelper.OracleIdentityExtra
+	for i := 0; i < n; i++ {
+		o := Oracle{Transmitter: solana.PublicKey{byte(10 + i)}}
+		o.Signer.Key[0] = byte(i + 1)
+		state.Oracles.Raw[i] = o
+
+		configKey, err := curve25519.X25519(bytes.Repeat([]byte{byte(i + 1)}, curve25519.ScalarSize), curve25519.Basepoint)
+		require.NoError(t, err)
+		identity := confighelper.OracleIdentityExtra{
+			OracleIdentity: confighelper.OracleIdentity{
+				OffchainPublicKey: types.OffchainPublicKey{byte(i + 1)},
+				OnchainPublicKey:  o.Signer.Key[:],
+				PeerID:            fmt.Sprintf("peer%d", i),
+				TransmitAccount:   types.Account(o.Transmitter.String()),
+			},
+		}
+		copy(identity.ConfigEncryptionPublicKey[:], configKey)
+		identities = append(identities, identity)
+	}
+	state.Oracles.Len = uint64(n)
+	return identities, state
+}
+
+func testOffchainConfigParams() OffchainConfigParams {
+	return OffchainConfigParams{
+		DeltaProgress:                           2 * time.Second,
+		DeltaResend:                             5 * time.Second,
+		DeltaRound:                              time.Second,
+		DeltaGrace:                              500 * time.Millisecond,
+		DeltaStage:                              10 * time.Second,
+		RMax:                                    3,
+		S:                                       []int{1, 1, 1, 1},
+		MaxDurationObservation:                  500 * time.Millisecond,
+		MaxDurationReport:                       500 * time.Millisecond,
+		MaxDurationShouldAcceptFinalizedReport:  500 * time.Millisecond,
+		MaxDurationShouldTransmitAcceptedReport: 500 * time.Millisecond,
+		Median:                                  median.OffchainConfig{AlphaReportPPB: 1000, AlphaAcceptPPB: 1000, DeltaC: time.Minute},
+	}
+}
+
+func TestOffchainConfigParams_Validate(t *testing.T) {
+	assert.NoError(t, testOffchainConfigParams().Validate(DefaultOffchainConfigBounds, 4, 1))
+
+	for name, modify := range map[string]func(*OffchainConfigParams){
+		"grace exceeds round":        func(p *OffchainConfigParams) { p.DeltaGrace = p.DeltaRound },
+		"round exceeds progress":     func(p *OffchainConfigParams) { p.DeltaRound = p.DeltaProgress },
+		"zero rmax":                  func(p *OffchainConfigParams) { p.RMax = 0 },
+		"rmax 255":                   func(p *OffchainConfigParams) { p.RMax = 255 },
+		"negative s":                 func(p *OffchainConfigParams) { p.S[0] = -1 },
+		"s exceeds max oracles":      func(p *OffchainConfigParams) { p.S[0] = types.MaxOracles + 1 },
+		"s too long":                 func(p *OffchainConfigParams) { p.S = make([]int, 1000) },
+		"negative grace":             func(p *OffchainConfigParams) { p.DeltaGrace = -time.Second },
+		"negative query":             func(p *OffchainConfigParams) { p.MaxDurationQuery = -time.Second },
+		"report generation too long": func(p *OffchainConfigParams) { p.MaxDurationQuery = time.Second },
+		"resend below safe interval": func(p *OffchainConfigParams) { p.DeltaResend = 100 * time.Millisecond },
+		"round below slot time":      func(p *OffchainConfigParams) { p.DeltaRound, p.DeltaGrace = 400*time.Millisecond, 100*time.Millisecond },
+		"stage too long":             func(p *OffchainConfigParams) { p.DeltaStage = time.Hour },
+		"observation too short":      func(p *OffchainConfigParams) { p.MaxDurationObservation = 0 },
+		"alpha too large":            func(p *OffchainConfigParams) { p.Median.AlphaAcceptPPB = 200_000_000 },
+	} {
+		p := testOffchainConfigParams()
+		modify(&p)
+		assert.Error(t, p.Validate(DefaultOffchainConfigBounds, 4, 1), name)
+	}
+
+	// oracle and fault counts
+	p := testOffchainConfigParams()
+	for _, c := range []struct{ n, f int }{{3, 1}, {4, -1}, {types.MaxOracles + 1, 1}} {
+		assert.Error(t, p.Validate(DefaultOffchainConfigBounds, c.n, c.f), "n %d f %d", c.n, c.f)
+	}
+	assert.NoError(t, p.Validate(DefaultOffchainConfigBounds, 1, 0))
+
+	// progress below the resource exhaustion interval, without bounds
+	p.DeltaProgress, p.DeltaRound, p.DeltaGrace, p.MaxDurationObservation, p.MaxDurationReport =
+		150*time.Millisecond, 100*time.Millisecond, 50*time.Millisecond, 10*time.Millisecond, 10*time.Millisecond
+	assert.Error(t, p.Validate(OffchainConfigBounds{}, 4, 1))
+	p.DeltaProgress = 200 * time.Millisecond
+	assert.NoError(t, p.Validate(OffchainConfigBounds{}, 4, 1))
+
+	// custom bounds
+	p = testOffchainConfigParams()
+	bounds := DefaultOffchainConfigBounds
+	bounds.DeltaC = DurationRange{Max: 30 * time.Second}
+	assert.Error(t, p.Validate(bounds, 4, 1))
+
+	// infinite alpha is not bounded
+	p.Median.AlphaReportInfinite, p.Median.AlphaReportPPB = true, 200_000_000
+	assert.NoError(t, p.Validate(DefaultOffchainConfigBounds, 4, 1))
+}
+
+func TestEncodeDecodeOffchainConfig(t *testing.T) {
+	identities, state := testOracleIdentities(t, 4)
+	params := testOffchainConfigParams()
+
+	version, offchainConfig, err := EncodeOffchainConfig(params, identities, 1)
+	require.NoError(t, err)
+	assert.NoError(t, ValidateOffchainConfigLen(offchainConfig))
+
+	state.OffchainConfig.Version = version
+	state.OffchainConfig.Len = uint64(copy(state.OffchainConfig.Raw[:], offchainConfig))
+	decoded, err := DecodeStateOffchainConfig(state)
+	require.NoError(t, err)
+	assert.Equal(t, params, decoded)
+
+	// invalid params are not encoded
+	params.RMax = 0
+	_, _, err = EncodeOffchainConfig(params, identities, 1)
+	assert.Error(t, err)
+	_, _, err = EncodeOffchainConfig(testOffchainConfigParams(), identities, 2)
+	assert.Error(t, err)
+
+	// oracle identities libocr rejects
+	identities[1].PeerID = identities[0].PeerID
+	_, _, err = EncodeOffchainConfig(testOffchainConfigParams(), identities, 1)
+	assert.Error(t, err)
+
+	// invalid config
+	state.OffchainConfig.Len = 10
+	_, err = DecodeStateOffchainConfig(state)
+	assert.Error(t, err)
+
+	assert.Error(t, ValidateOffchainConfigLen(nil))
+	assert.Error(t, ValidateOffchainConfigLen(make([]byte, MaxOffchainConfigLen)))
+}
+
+func TestNewWriteOffchainConfigInstructions(t *testing.T) {
+	chunkLen, err := OffchainConfigChunkLen()
+	require.NoError(t, err)
+
+	programID, proposal, authority, payer := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	offchainConfig := make([]byte, 2*chunkLen+1)
+	for i := range offchainConfig {
+		offchainConfig[i] = byte(i)
+	}
+	ixs, err := NewWriteOffchainConfigInstructions(programID, proposal, authority, offchainConfig)
+	require.NoError(t, err)
+	require.Len(t, ixs, 3)
+
+	var written []byte
+	for i, ix := range ixs {
+		assert.Equal(t, programID, ix.ProgramID())
+		data, err := ix.Data()
+		require.NoError(t, err)
+		// discriminator, borsh vec length, chunk
+		written = append(written, data[8+4:]...)
+
+		// full chunks fill a transaction exactly
+		tx, err := solana.NewTransaction([]solana.Instruction{ix}, solana.Hash{}, solana.TransactionPayer(payer))
+		require.NoError(t, err)
+		size, err := TxSize(tx.Message)
+		require.NoError(t, err)
+		if i < 2 {
+			assert.Equal(t, MaxTxSize, size)
+		}
+	}
+	assert.Equal(t, offchainConfig, written)
+}
+
+// testStateAccount returns the account info response holding state
+func testStateAccount(t *testing.T, state State) *rpc.GetAccountInfoResult {
+	var buf bytes.Buffer
+	require.NoError(t, bin.NewBinEncoder(&buf).Encode(state))
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	require.NoError(t, err)
+	return &rpc.GetAccountInfoResult{Value: &rpc.Account{Data: data}}
+}
+
+// expectStateReads mocks the config poll of the state account and, if full, the full read following a config change
+func expectStateReads(t *testing.T, reader *mocks.ReaderWriter, account solana.PublicKey, state State, full bool) {
+	res := testStateAccount(t, state)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, account, mock.MatchedBy(func(opts *rpc.GetAccountInfoOpts) bool {
+		return opts.DataSlice != nil
+	})).Return(res, nil).Once()
+	if full {
+		reader.On("GetAccountInfoWithOpts", mock.Anything, account, mock.MatchedBy(func(opts *rpc.GetAccountInfoOpts) bool {
+			return opts.DataSlice == nil
+		})).Return(res, nil).Once()
+	}
+}
+
+func TestContractTracker_CheckOffchainConfig(t *testing.T) {
+	identities, state := testOracleIdentities(t, 4)
+	version, offchainConfig, err := EncodeOffchainConfig(testOffchainConfigParams(), identities, 1)
+	require.NoError(t, err)
+	state.OffchainConfig.Version = version
+	state.OffchainConfig.Len = uint64(copy(state.OffchainConfig.Raw[:], offchainConfig))
+
+	lggr := logger.TestLogger(t)
+	reader := new(mocks.ReaderWriter)
+	// bootstrap tracker without transmitter
+	tracker := NewTracker(OCR2Spec{StateID: solana.NewWallet().PublicKey()}, config.NewConfig(db.ChainCfg{}, lggr), reader, nil, nil, lggr)
+	// chain config defaults match the default bounds
+	assert.Equal(t, DefaultOffchainConfigBounds, tracker.offchainConfigBounds())
+	fetch := func(digest byte) {
+		full := state.Config.LatestConfigDigest != [32]byte{digest}
+		state.Config.LatestConfigDigest = [32]byte{digest}
+		expectStateReads(t, reader, tracker.StateID, state, full)
+		require.NoError(t, tracker.fetchState(context.Background()))
+	}
+
+	// new config within bounds
+	fetch(1)
+	assert.NoError(t, tracker.health.Err())
+
+	// new config outside the configured bounds
+	minDeltaRound := models.MustMakeDuration(2 * time.Second)
+	tracker.cfg.Update(db.ChainCfg{OffchainConfigMinDeltaRound: &minDeltaRound})
+	fetch(2)
+	require.Error(t, tracker.health.Err())
+	assert.Contains(t, tracker.health.Err().Error(), "DeltaRound")
+
+	// checked once per config digest
+	tracker.cfg.Update(db.ChainCfg{})
+	fetch(2)
+	assert.Error(t, tracker.health.Err())
+	fetch(3)
+	assert.NoError(t, tracker.health.Err())
+
+	// undecodable config
+	state.OffchainConfig.Len = 10
+	fetch(4)
+	assert.Error(t, tracker.health.Err())
+	reader.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package solana
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// health report keys for job spec and on-chain config drift
+const (
+	healthKeyOracleSet    = "oracleSet"
+	healthKeyConfigDigest = "configDigest"
+)
+
+// TransmitterNotInOracleSetError is returned when the job's transmitter is not part of the cached on-chain oracle set
+type TransmitterNotInOracleSetError struct {
+	Transmitter  solana.PublicKey
+	ConfigDigest types.ConfigDigest
+}
+
+func (e *TransmitterNotInOracleSetError) Error() string {
+	return fmt.Sprintf("transmitter %s is not in the on-chain oracle set for config %s", e.Transmitter, e.ConfigDigest)
+}
+
+// ConfigDigestMismatchError is returned when a report was generated for a config that is not the latest on-chain config
+type ConfigDigestMismatchError struct {
+	Report   types.ConfigDigest
+	OnChain  types.ConfigDigest
+	Replaced bool // the report config was observed on-chain before the latest one
+}
+
+func (e *ConfigDigestMismatchError) Error() string {
+	return fmt.Sprintf("report config digest %s does not match on-chain config digest %s", e.Report, e.OnChain)
+}
+
+// checkTransmitter verifies the transmitter is one of the oracles in state and reports the result through Healthy.
+// The drift is logged when it starts and ends only, the check runs on every poll.
+func (c *ContractTracker) checkTransmitter(state State) error {
+	oracles, err := state.Oracles.Data()
+	if err != nil {
+		return err
+	}
+	transmitter := c.Transmitter.PublicKey()
+	for _, o := range oracles {
+		if o.Transmitter.Equals(transmitter) {
+			if c.health.Set(healthKeyOracleSet, nil) {
+				c.lggr.Infof("transmitter %s is in the on-chain oracle set for config %s again", transmitter, types.ConfigDigest(state.Config.LatestConfigDigest))
+			}
+			return nil
+		}
+	}
+	notFound := &TransmitterNotInOracleSetError{
+		Transmitter:  transmitter,
+		ConfigDigest: state.Config.LatestConfigDigest,
+	}
+	if c.health.Set(healthKeyOracleSet, notFound) {
+		c.lggr.Errorf("job spec and on-chain config drift: %s", notFound)
+	}
+	return notFound
+}
+
+// checkConfigDigest verifies the report digest matches the latest on-chain config.
+// Reports of a config replaced since are expected right after a config change and do not affect Healthy,
+// a report of an unknown config is reported through Healthy until a report matches or the poller sees a new config.
+func (c *ContractTracker) checkConfigDigest(state State, digest types.ConfigDigest) error {
+	if digest != state.Config.LatestConfigDigest {
+		_, replaced := c.configs.Get(digest)
+		mismatch := &ConfigDigestMismatchError{
+			Report:   digest,
+			OnChain:  state.Config.LatestConfigDigest,
+			Replaced: replaced,
+		}
+		if !replaced {
+			c.health.Set(healthKeyConfigDigest, mismatch)
+		}
+		return mismatch
+	}
+	c.health.Set(healthKeyConfigDigest, nil)
+	return nil
+}
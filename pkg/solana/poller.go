@@ -0,0 +1,329 @@
+package solana
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/chainlink/core/utils"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+// feedPoller polls and caches the state and transmissions accounts of a feed.
+// It is shared by the trackers of all jobs watching the feed and polls while at least one of them is started.
+type feedPoller struct {
+	programID       solana.PublicKey
+	stateID         solana.PublicKey
+	transmissionsID solana.PublicKey
+
+	// tracked contract state
+	state  State
+	answer Answer
+
+	// report layout accepted by the program of the latest config, selected from reportVersions
+	reportVersion  ReportVersion
+	reportVersions ProgramReportVersions
+
+	// transmissions account reader
+	feed *Feed
+
+	// program events of the state account
+	events *events.Fetcher
+
+	// latest round requested, read from program events of the requester access controller
+	roundRequests *roundRequests
+
+	// snapshots of observed configs for LatestConfig
+	configs *configHistory
+
+	// read/write mutexes
+	stateLock *sync.RWMutex
+	ansLock   *sync.RWMutex
+
+	// stale state parameters
+	stateTime time.Time
+	ansTime   time.Time
+
+	// dependencies
+	reader client.Reader
+	cfg    config.Config
+	lggr   logger.Logger
+
+	// started trackers
+	subscribers     map[*ContractTracker]struct{}
+	subscribersLock sync.Mutex
+
+	// polling, runLock serializes starting and stopping it
+	runLock sync.Mutex
+	done    chan struct{}
+	cancel  context.CancelFunc
+}
+
+func newFeedPoller(spec OCR2Spec, cfg config.Config, reader client.Reader, lggr logger.Logger) *feedPoller {
+	fetcher := events.NewFetcher(reader, spec.ProgramID, spec.StateID, cfg.Commitment(), lggr)
+	return &feedPoller{
+		programID:       spec.ProgramID,
+		stateID:         spec.StateID,
+		transmissionsID: spec.TransmissionsID,
+		feed:            NewFeed(reader, spec.StoreProgramID, spec.TransmissionsID, cfg.Commitment()),
+		events:          fetcher,
+		roundRequests:   newRoundRequests(reader, spec.ProgramID, cfg.Commitment(), lggr),
+		configs:         newConfigHistory(),
+		reportVersion:   ReportV1,
+		reportVersions:  DefaultProgramReportVersions(),
+		stateLock:       &sync.RWMutex{},
+		ansLock:         &sync.RWMutex{},
+		reader:          reader,
+		cfg:             cfg,
+		lggr:            lggr,
+		subscribers:     map[*ContractTracker]struct{}{},
+	}
+}
+
+// subscribe adds a started tracker, polling starts with the first one
+func (p *feedPoller) subscribe(t *ContractTracker) {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+
+	p.subscribersLock.Lock()
+	p.subscribers[t] = struct{}{}
+	first := len(p.subscribers) == 1
+	p.subscribersLock.Unlock()
+
+	if !first {
+		// the tracker joins a running poller, check the cached state for its job
+		p.stateLock.RLock()
+		state, loaded := p.state, !p.stateTime.IsZero()
+		p.stateLock.RUnlock()
+		if loaded {
+			t.onState(state, true)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// We synchronously update the config on start so that
+	// when OCR starts there is config available (if possible).
+	// Avoids confusing "contract has not been configured" OCR errors.
+	state, changed, err := p.fetchState(ctx)
+	if err != nil {
+		p.lggr.Warnf("error in initial PollState.fetchState %s", err)
+	} else {
+		t.onState(state, changed)
+	}
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.poll(ctx, p.done)
+}
+
+// unsubscribe removes a stopped tracker, polling stops with the last one
+func (p *feedPoller) unsubscribe(t *ContractTracker) {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+
+	p.subscribersLock.Lock()
+	delete(p.subscribers, t)
+	remaining := len(p.subscribers)
+	p.subscribersLock.Unlock()
+
+	if remaining == 0 {
+		p.stopPolling()
+	}
+}
+
+// stop stops polling regardless of subscribers, it is called once no job uses the poller
+func (p *feedPoller) stop() {
+	p.runLock.Lock()
+	defer p.runLock.Unlock()
+
+	p.subscribersLock.Lock()
+	p.subscribers = map[*ContractTracker]struct{}{}
+	p.subscribersLock.Unlock()
+
+	p.stopPolling()
+}
+
+// stopPolling cancels the poll loop and waits for it to return, runLock must be held
+func (p *feedPoller) stopPolling() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	<-p.done
+	p.cancel, p.done = nil, nil
+}
+
+func (p *feedPoller) trackers() []*ContractTracker {
+	p.subscribersLock.Lock()
+	defer p.subscribersLock.Unlock()
+	trackers := make([]*ContractTracker, 0, len(p.subscribers))
+	for t := range p.subscribers {
+		trackers = append(trackers, t)
+	}
+	return trackers
+}
+
+// poll contains the state and transmissions polling implementation
+func (p *feedPoller) poll(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	p.lggr.Debugf("Starting state polling for state: %s, transmissions: %s", p.stateID, p.transmissionsID)
+	tick := time.After(0)
+	for {
+		select {
+		case <-ctx.Done():
+			p.lggr.Debugf("Stopping state polling for state: %s, transmissions: %s", p.stateID, p.transmissionsID)
+			return
+		case <-tick:
+			// async poll both transmission + ocr2 states
+			start := time.Now()
+			var (
+				wg       sync.WaitGroup
+				state    State
+				changed  bool
+				stateErr error
+			)
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				state, changed, stateErr = p.fetchState(ctx)
+				if stateErr != nil {
+					p.lggr.Errorf("error in PollState.fetchState %s", stateErr)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				err := p.fetchLatestTransmission(ctx)
+				if err != nil {
+					p.lggr.Errorf("error in PollState.fetchLatestTransmission %s", err)
+				}
+			}()
+			wg.Wait()
+
+			for _, t := range p.trackers() {
+				if stateErr == nil {
+					t.onState(state, changed)
+				}
+				if t.auditLog != nil {
+					t.resolveAuditStatus(ctx)
+				}
+			}
+
+			// Note negative duration will be immediately ready
+			tick = time.After(utils.WithJitter(p.cfg.OCR2CachePollPeriod()) - time.Since(start))
+		}
+	}
+}
+
+// ReadState reads the latest state from memory with mutex and errors if timeout is exceeded
+func (p *feedPoller) ReadState() (State, error) {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
+
+	var err error
+	if time.Since(p.stateTime) > p.cfg.OCR2CacheTTL() {
+		err = errors.New("error in ReadState: stale state data, polling is likely experiencing errors")
+	}
+	return p.state, err
+}
+
+// ReadAnswer reads the latest state from memory with mutex and errors if timeout is exceeded
+func (p *feedPoller) ReadAnswer() (Answer, error) {
+	p.ansLock.RLock()
+	defer p.ansLock.RUnlock()
+
+	// check if stale timeout
+	var err error
+	if time.Since(p.ansTime) > p.cfg.OCR2CacheTTL() {
+		err = errors.New("error in ReadAnswer: stale answer data, polling is likely experiencing errors")
+	}
+	return p.answer, err
+}
+
+// fetch + decode + store raw state, changed reports a new config digest
+func (p *feedPoller) fetchState(ctx context.Context) (state State, changed bool, err error) {
+	p.lggr.Debugf("fetch state for account: %s", p.stateID.String())
+	state, err = p.pollState(ctx)
+	if err != nil {
+		return State{}, false, err
+	}
+
+	p.lggr.Debugf("state fetched for account: %s, result (config digest): %v", p.stateID, hex.EncodeToString(state.Config.LatestConfigDigest[:]))
+
+	p.stateLock.RLock()
+	prev, reportVersion := p.state, p.reportVersion
+	p.stateLock.RUnlock()
+	changed = state.Config.LatestConfigDigest != prev.Config.LatestConfigDigest
+	if changed {
+		if cfg, err := ConfigFromState(state); err != nil {
+			p.lggr.Errorf("error in fetchState.ConfigFromState %s", err)
+		} else {
+			p.configs.Add(state.Config.LatestConfigBlockNumber, cfg)
+		}
+	}
+	// the report layout is selected with the config, the last known layout is kept if the program version is unknown
+	if changed || state.Version != prev.Version {
+		if version, err := p.reportVersions.ForState(state); err != nil {
+			p.lggr.Errorf("error in fetchState.ForState, keeping report version %d: %s", reportVersion, err)
+		} else {
+			reportVersion = version
+		}
+	}
+
+	// acquire lock and write to state
+	p.stateLock.Lock()
+	defer p.stateLock.Unlock()
+	p.state = state
+	p.stateTime = time.Now()
+	p.reportVersion = reportVersion
+	return state, changed, nil
+}
+
+// ReportVersion returns the report layout selected for the latest config, it remains available while the state is stale
+func (p *feedPoller) ReportVersion() ReportVersion {
+	p.stateLock.RLock()
+	defer p.stateLock.RUnlock()
+	return p.reportVersion
+}
+
+// pollState reads only the config region of the state account, the offchain config and oracles
+// are read again only when the config digest or count changes
+func (p *feedPoller) pollState(ctx context.Context) (State, error) {
+	p.stateLock.RLock()
+	prev, loaded := p.state, !p.stateTime.IsZero()
+	p.stateLock.RUnlock()
+
+	state, _, err := GetStateConfig(ctx, p.reader, p.programID, p.stateID, p.cfg.Commitment())
+	if err != nil && !errors.Is(err, ErrStateLayout) {
+		return State{}, err
+	}
+	if err == nil && loaded && state.Config.LatestConfigDigest == prev.Config.LatestConfigDigest && state.Config.ConfigCount == prev.Config.ConfigCount {
+		state.OffchainConfig = prev.OffchainConfig
+		state.Oracles = prev.Oracles
+		return state, nil
+	}
+	state, _, err = GetState(ctx, p.reader, p.programID, p.stateID, p.cfg.Commitment())
+	return state, err
+}
+
+func (p *feedPoller) fetchLatestTransmission(ctx context.Context) error {
+	p.lggr.Debugf("fetch latest transmission for account: %s", p.transmissionsID)
+	answer, _, err := p.feed.LatestTransmission(ctx)
+	if err != nil {
+		return err
+	}
+	p.lggr.Debugf("latest transmission fetched for account: %s, result: %v", p.transmissionsID, answer)
+
+	// acquire lock and write to state
+	p.ansLock.Lock()
+	defer p.ansLock.Unlock()
+	p.answer = answer
+	p.ansTime = time.Now()
+	return nil
+}
@@ -0,0 +1,163 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/chainlink/core/store/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+)
+
+func TestFeedPoller_Shared(t *testing.T) {
+	_, state := testOracleIdentities(t, 4)
+	state.Config.LatestConfigDigest = [32]byte{1}
+	spec := OCR2Spec{ChainID: "localnet", StateID: solana.NewWallet().PublicKey(), TransmissionsID: solana.NewWallet().PublicKey()}
+	lggr := logger.TestLogger(t)
+	cfg := config.NewConfig(db.ChainCfg{}, lggr)
+	reader := new(mocks.ReaderWriter)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, spec.StateID, mock.Anything).Return(testStateAccount(t, state), nil)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, spec.TransmissionsID, mock.Anything).Return(nil, errors.New("not found"))
+
+	// jobs of the same feed share a poller
+	relayer := &Relayer{lggr: lggr, pollers: map[pollerKey]*sharedPoller{}}
+	poller, releaseA := relayer.acquirePoller(spec, cfg, reader)
+	shared, releaseB := relayer.acquirePoller(spec, cfg, reader)
+	require.Same(t, poller, shared)
+	other, releaseOther := relayer.acquirePoller(OCR2Spec{ChainID: "devnet", StateID: spec.StateID, TransmissionsID: spec.TransmissionsID}, cfg, reader)
+	assert.NotSame(t, poller, other)
+
+	a := newTracker(spec, poller, cfg, reader, nil, nil, lggr)
+	b := newTracker(spec, poller, cfg, reader, nil, nil, lggr)
+	require.NoError(t, a.Start())
+	st, err := b.ReadState()
+	require.NoError(t, err)
+	assert.Equal(t, state.Config.LatestConfigDigest, st.Config.LatestConfigDigest)
+	done := poller.done
+
+	// a job joining a running poller checks the cached config
+	require.NoError(t, b.Start())
+	assert.Equal(t, done, poller.done)
+	require.Error(t, b.health.Err())
+	assert.Contains(t, b.health.Err().Error(), "failed to decode offchain config")
+
+	// polling stops with the last tracker
+	require.NoError(t, a.Close())
+	select {
+	case <-done:
+		t.Fatal("poller stopped while shared")
+	default:
+	}
+	require.NoError(t, b.Close())
+	<-done
+
+	releaseA()
+	releaseA() // released once per job
+	assert.Len(t, relayer.pollers, 2)
+	releaseB()
+	releaseOther()
+	assert.Empty(t, relayer.pollers)
+}
+
+func TestFeedPoller_ConcurrentStartClose(t *testing.T) {
+	_, state := testOracleIdentities(t, 4)
+	spec := OCR2Spec{StateID: solana.NewWallet().PublicKey(), TransmissionsID: solana.NewWallet().PublicKey()}
+	lggr := logger.TestLogger(t)
+	period := models.MustMakeDuration(time.Millisecond)
+	cfg := config.NewConfig(db.ChainCfg{OCR2CachePollPeriod: &period}, lggr)
+
+	// a single poll loop or initial fetch reads the state account at a time
+	var inflight, overlaps int32
+	reader := new(mocks.ReaderWriter)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, spec.StateID, mock.Anything).Run(func(mock.Arguments) {
+		if atomic.AddInt32(&inflight, 1) > 1 {
+			atomic.AddInt32(&overlaps, 1)
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&inflight, -1)
+	}).Return(testStateAccount(t, state), nil)
+	reader.On("GetAccountInfoWithOpts", mock.Anything, spec.TransmissionsID, mock.Anything).Return(nil, errors.New("not found"))
+
+	relayer := &Relayer{lggr: lggr, pollers: map[pollerKey]*sharedPoller{}}
+	poller, release := relayer.acquirePoller(spec, cfg, reader)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				tracker := newTracker(spec, poller, cfg, reader, nil, nil, lggr)
+				assert.NoError(t, tracker.Start())
+				assert.NoError(t, tracker.Close())
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Zero(t, atomic.LoadInt32(&overlaps))
+	assert.Empty(t, poller.trackers())
+	assert.Nil(t, poller.done, "polling stopped with the last tracker")
+
+	// releasing the last job stops polling of trackers that were not closed
+	tracker := newTracker(spec, poller, cfg, reader, nil, nil, lggr)
+	require.NoError(t, tracker.Start())
+	done := poller.done
+	release()
+	<-done
+	assert.Empty(t, relayer.pollers)
+}
+
+func TestFeedPoller_ReportVersion(t *testing.T) {
+	_, state := testOracleIdentities(t, 4)
+	spec := OCR2Spec{StateID: solana.NewWallet().PublicKey()}
+	lggr := logger.TestLogger(t)
+	ttl := models.MustMakeDuration(time.Millisecond)
+	cfg := config.NewConfig(db.ChainCfg{OCR2CacheTTL: &ttl}, lggr)
+	reader := new(mocks.ReaderWriter)
+	poller := newFeedPoller(spec, cfg, reader, lggr)
+	codec := NewVersionedReportCodec(poller.ReportVersion)
+
+	fetch := func(digest byte) {
+		full := state.Config.LatestConfigDigest != [32]byte{digest}
+		state.Config.LatestConfigDigest = [32]byte{digest}
+		expectStateReads(t, reader, spec.StateID, state, full)
+		_, _, err := poller.fetchState(context.Background())
+		require.NoError(t, err)
+	}
+
+	fetch(1)
+	assert.Equal(t, ReportV1, poller.ReportVersion())
+
+	// the layout is selected when the config changes
+	poller.reportVersions = ProgramReportVersions{1: 9}
+	fetch(1)
+	assert.Equal(t, ReportV1, poller.ReportVersion())
+	fetch(2)
+	assert.Equal(t, ReportVersion(9), poller.ReportVersion())
+
+	// an unknown program version keeps the last known layout
+	poller.reportVersions = ProgramReportVersions{}
+	fetch(3)
+	assert.Equal(t, ReportVersion(9), poller.ReportVersion())
+	poller.reportVersions = DefaultProgramReportVersions()
+	fetch(4)
+	assert.Equal(t, ReportV1, poller.ReportVersion())
+
+	// reports are built with the last known layout while the state is stale
+	time.Sleep(2 * time.Millisecond)
+	_, err := poller.ReadState()
+	require.Error(t, err)
+	report, err := codec.BuildReport(testObservationsWithValues(1, 2, 3))
+	require.NoError(t, err)
+	assert.Len(t, report, codec.MaxReportLength(3))
+}
@@ -0,0 +1,14 @@
+package proposal
+
+import (
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+)
+
+// Digest returns the digest accept_proposal expects for a proposal holding cfg
+func Digest(cfg Config) []byte {
+	oracles := make([]relaySol.ProposalOracle, len(cfg.Oracles))
+	for i, o := range cfg.Oracles {
+		oracles[i] = relaySol.ProposalOracle(o)
+	}
+	return relaySol.ProposalDigestFromConfig(oracles, cfg.F, cfg.TokenMint, cfg.OffchainConfigVersion, cfg.OffchainConfig)
+}
@@ -0,0 +1,493 @@
+// Package proposal runs the OCR2 program config proposal lifecycle:
+// create_proposal, propose_config, propose_payees, write_offchain_config, finalize_proposal and accept_proposal.
+// Every step checks the on-chain proposal first, so an interrupted run can be resumed with the same inputs.
+package proposal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+const (
+	// AccountSize = discriminator + version, owner, state, f, padding0, padding1, token mint, proposed oracles, offchain config
+	AccountSize uint64 = relaySol.AccountDiscriminatorLen + 1 + 32 + 1 + 1 + 1 + 4 + 32 +
+		proposedOracleLen*relaySol.MaxOracles + 8 +
+		8 + relaySol.MaxOffchainConfigLen + 8
+	// proposedOracleLen = transmitter, signer, padding, payee
+	proposedOracleLen = 32 + 20 + 4 + 32
+
+	// proposal states
+	stateNew       uint8 = 0
+	stateFinalized uint8 = 1
+)
+
+// Oracle is an oracle of the proposed config
+type Oracle struct {
+	Signer      [20]byte
+	Transmitter solana.PublicKey
+	Payee       solana.PublicKey // token account for TokenMint
+}
+
+// Config is the desired config of a proposal
+type Config struct {
+	Oracles               []Oracle
+	F                     uint8
+	TokenMint             solana.PublicKey
+	OffchainConfigVersion uint64
+	OffchainConfig        []byte
+}
+
+// Validate applies the checks performed by the program
+func (c Config) Validate() error {
+	n := len(c.Oracles)
+	if n > relaySol.MaxOracles {
+		return fmt.Errorf("too many oracles: %d (max %d)", n, relaySol.MaxOracles)
+	}
+	if c.F == 0 || 3*int(c.F) >= n {
+		return fmt.Errorf("f %d out of range for %d oracles (requires 0 < 3f < n)", c.F, n)
+	}
+	signers := map[[20]byte]bool{}
+	transmitters := map[solana.PublicKey]bool{}
+	for _, o := range c.Oracles {
+		if signers[o.Signer] {
+			return fmt.Errorf("duplicate signer %x", o.Signer)
+		}
+		if transmitters[o.Transmitter] {
+			return fmt.Errorf("duplicate transmitter %s", o.Transmitter)
+		}
+		if o.Payee.IsZero() {
+			return fmt.Errorf("missing payee for transmitter %s", o.Transmitter)
+		}
+		signers[o.Signer] = true
+		transmitters[o.Transmitter] = true
+	}
+	if c.OffchainConfigVersion == 0 {
+		return errors.New("offchain config version must be set")
+	}
+	return relaySol.ValidateOffchainConfigLen(c.OffchainConfig)
+}
+
+// sortedOracles returns the oracles in the order stored by the program: sorted by signer
+func (c Config) sortedOracles() []Oracle {
+	oracles := append([]Oracle{}, c.Oracles...)
+	sort.Slice(oracles, func(i, j int) bool {
+		return bytes.Compare(oracles[i].Signer[:], oracles[j].Signer[:]) < 0
+	})
+	return oracles
+}
+
+// ErrAccepted is returned by Propose when the proposal account does not exist and the state already holds the config,
+// as when a Run is resumed after accept_proposal closed the proposal
+var ErrAccepted = errors.New("config already accepted")
+
+// Signer signs transactions, it is implemented by solana wallets, KMS or hardware backed keys
+type Signer interface {
+	PublicKey() solana.PublicKey
+	Sign(msg []byte) ([]byte, error)
+}
+
+// Client is the subset of the RPC client used by the Proposer, it is satisfied by *rpc.Client
+type Client interface {
+	client.AccountReader
+	GetMinimumBalanceForRentExemption(ctx context.Context, dataSize uint64, commitment rpc.CommitmentType) (uint64, error)
+	GetLatestBlockhash(ctx context.Context, commitment rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error)
+	SendTransactionWithOpts(ctx context.Context, tx *solana.Transaction, skipPreflight bool, preflightCommitment rpc.CommitmentType) (solana.Signature, error)
+	GetSignatureStatuses(ctx context.Context, searchTransactionHistory bool, sigs ...solana.Signature) (*rpc.GetSignatureStatusesResult, error)
+}
+
+// Proposer runs the proposal lifecycle for an OCR2 state account
+type Proposer struct {
+	client     Client
+	programID  solana.PublicKey
+	stateID    solana.PublicKey
+	authority  Signer // state and proposal owner
+	payer      Signer
+	commitment rpc.CommitmentType
+	pollPeriod time.Duration
+	txTimeout  time.Duration
+	lggr       logger.Logger
+}
+
+// NewProposer returns a Proposer for stateID, authority must own the state account
+func NewProposer(c Client, programID, stateID solana.PublicKey, authority, payer Signer, lggr logger.Logger) *Proposer {
+	return &Proposer{
+		client:     c,
+		programID:  programID,
+		stateID:    stateID,
+		authority:  authority,
+		payer:      payer,
+		commitment: rpc.CommitmentConfirmed,
+		pollPeriod: time.Second,
+		txTimeout:  time.Minute,
+		lggr:       lggr,
+	}
+}
+
+// Run proposes cfg in the proposal account and accepts it once the on-chain digest matches cfg
+func (p *Proposer) Run(ctx context.Context, proposal Signer, cfg Config) error {
+	digest, err := p.Propose(ctx, proposal, cfg)
+	if errors.Is(err, ErrAccepted) {
+		p.lggr.Infof("config of proposal %s is already accepted for state %s", proposal.PublicKey(), p.stateID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return p.Accept(ctx, proposal.PublicKey(), digest)
+}
+
+// Propose creates or resumes the proposal until it is finalized with cfg and returns its digest.
+// The proposal signer is only used when the proposal account does not exist yet.
+// ErrAccepted is returned instead of creating a proposal for the config the state already holds.
+func (p *Proposer) Propose(ctx context.Context, proposal Signer, cfg Config) ([]byte, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid proposal config")
+	}
+	proposalID := proposal.PublicKey()
+	oracles := cfg.sortedOracles()
+
+	current, err := p.ReadProposal(ctx, proposalID)
+	if errors.Is(err, rpc.ErrNotFound) {
+		var accepted bool
+		if accepted, err = p.accepted(ctx, cfg); err != nil {
+			return nil, err
+		}
+		if accepted {
+			return nil, ErrAccepted
+		}
+		p.lggr.Infof("creating proposal %s", proposalID)
+		if err = p.create(ctx, proposal, cfg.OffchainConfigVersion); err != nil {
+			return nil, err
+		}
+		current, err = p.ReadProposal(ctx, proposalID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !current.Owner.Equals(p.authority.PublicKey()) {
+		return nil, fmt.Errorf("proposal %s is owned by %s, not %s", proposalID, current.Owner, p.authority.PublicKey())
+	}
+	if current.OffchainConfig.Version != cfg.OffchainConfigVersion {
+		return nil, fmt.Errorf("proposal %s has offchain config version %d, expected %d: close it and start over", proposalID, current.OffchainConfig.Version, cfg.OffchainConfigVersion)
+	}
+
+	expected := Digest(cfg)
+	if current.State == stateFinalized {
+		actual, err := relaySol.ProposalDigest(current)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(actual, expected) {
+			return nil, fmt.Errorf("proposal %s is finalized with a different config: close it and start over", proposalID)
+		}
+		return expected, nil
+	}
+
+	if !oraclesMatch(current, oracles, cfg.F) {
+		p.lggr.Infof("proposing %d oracles with f=%d for proposal %s", len(oracles), cfg.F, proposalID)
+		newOracles := make([]ocr_2.NewOracle, len(oracles))
+		for i, o := range oracles {
+			newOracles[i] = ocr_2.NewOracle{Signer: o.Signer, Transmitter: o.Transmitter}
+		}
+		if err = p.send(ctx, []solana.Instruction{
+			ocr_2.NewProposeConfigInstruction(newOracles, cfg.F, proposalID, p.authority.PublicKey()).Build(),
+		}); err != nil {
+			return nil, errors.Wrap(err, "error in Propose.ProposeConfig")
+		}
+		// payees are reset by propose_config
+		if current, err = p.ReadProposal(ctx, proposalID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !payeesMatch(current, oracles, cfg.TokenMint) {
+		p.lggr.Infof("proposing payees for proposal %s", proposalID)
+		payees := make([]solana.PublicKey, len(oracles))
+		for i, o := range oracles {
+			payees[i] = o.Payee
+		}
+		if err = p.send(ctx, []solana.Instruction{
+			ocr_2.NewProposePayeesInstruction(cfg.TokenMint, payees, proposalID, p.authority.PublicKey()).Build(),
+		}); err != nil {
+			return nil, errors.Wrap(err, "error in Propose.ProposePayees")
+		}
+	}
+
+	// offchain config can only be appended to
+	if current.OffchainConfig.Len > uint64(len(current.OffchainConfig.Xs)) {
+		return nil, fmt.Errorf("proposal offchain config length %d exceeds %d", current.OffchainConfig.Len, len(current.OffchainConfig.Xs))
+	}
+	written := current.OffchainConfig.Xs[:current.OffchainConfig.Len]
+	if !bytes.HasPrefix(cfg.OffchainConfig, written) {
+		return nil, fmt.Errorf("proposal %s holds a different offchain config: close it and start over", proposalID)
+	}
+	writes, err := relaySol.NewWriteOffchainConfigInstructions(p.programID, proposalID, p.authority.PublicKey(), cfg.OffchainConfig[len(written):])
+	if err != nil {
+		return nil, err
+	}
+	for i, ix := range writes {
+		p.lggr.Infof("writing offchain config chunk %d of %d for proposal %s", i+1, len(writes), proposalID)
+		if err = p.send(ctx, []solana.Instruction{ix}); err != nil {
+			return nil, errors.Wrap(err, "error in Propose.WriteOffchainConfig")
+		}
+	}
+
+	p.lggr.Infof("finalizing proposal %s", proposalID)
+	if err = p.send(ctx, []solana.Instruction{
+		ocr_2.NewFinalizeProposalInstruction(proposalID, p.authority.PublicKey()).Build(),
+	}); err != nil {
+		return nil, errors.Wrap(err, "error in Propose.FinalizeProposal")
+	}
+
+	// verify what was written on-chain is what was intended
+	if current, err = p.ReadProposal(ctx, proposalID); err != nil {
+		return nil, err
+	}
+	actual, err := relaySol.ProposalDigest(current)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(actual, expected) {
+		return nil, fmt.Errorf("proposal %s digest %x does not match expected digest %x", proposalID, actual, expected)
+	}
+	return expected, nil
+}
+
+// accepted returns true if the state holds cfg, checked through the digest of its oracles and offchain config
+func (p *Proposer) accepted(ctx context.Context, cfg Config) (bool, error) {
+	state, _, err := relaySol.GetState(ctx, p.client, p.programID, p.stateID, p.commitment)
+	if err != nil {
+		return false, errors.Wrap(err, "error in Propose.GetState")
+	}
+	stateOracles, err := state.Oracles.Data()
+	if err != nil {
+		return false, err
+	}
+	offchainConfig, err := state.OffchainConfig.Data()
+	if err != nil {
+		return false, err
+	}
+	oracles := make([]relaySol.ProposalOracle, len(stateOracles))
+	for i, o := range stateOracles {
+		oracles[i] = relaySol.ProposalOracle{Signer: o.Signer.Key, Transmitter: o.Transmitter, Payee: o.Payee}
+	}
+	digest := relaySol.ProposalDigestFromConfig(oracles, state.Config.F, state.Config.TokenMint, state.OffchainConfig.Version, offchainConfig)
+	return bytes.Equal(digest, Digest(cfg)), nil
+}
+
+// Accept accepts a finalized proposal after checking its on-chain digest, paying out the current oracles.
+// The proposal account is closed by the program and its rent returned to the payer.
+func (p *Proposer) Accept(ctx context.Context, proposalID solana.PublicKey, digest []byte) error {
+	current, err := p.ReadProposal(ctx, proposalID)
+	if err != nil {
+		return err
+	}
+	if current.State != stateFinalized {
+		return fmt.Errorf("proposal %s is not finalized", proposalID)
+	}
+	actual, err := relaySol.ProposalDigest(current)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(actual, digest) {
+		return fmt.Errorf("proposal %s digest %x does not match expected digest %x", proposalID, actual, digest)
+	}
+
+	state, _, err := relaySol.GetState(ctx, p.client, p.programID, p.stateID, p.commitment)
+	if err != nil {
+		return errors.Wrap(err, "error in Accept.GetState")
+	}
+	vaultAuthority, _, err := solana.FindProgramAddress([][]byte{[]byte("vault"), p.stateID.Bytes()}, p.programID)
+	if err != nil {
+		return errors.Wrap(err, "error in Accept.FindProgramAddress")
+	}
+	accept := ocr_2.NewAcceptProposalInstruction(
+		digest,
+		p.stateID,
+		proposalID,
+		p.payer.PublicKey(), // receiver of the proposal rent
+		p.authority.PublicKey(),
+		state.Config.TokenVault,
+		vaultAuthority,
+		solana.TokenProgramID,
+	)
+	// the current oracles are paid out before their config is replaced
+	oracles, err := state.Oracles.Data()
+	if err != nil {
+		return err
+	}
+	for _, o := range oracles {
+		accept.AccountMetaSlice.Append(solana.Meta(o.Payee).WRITE())
+	}
+
+	p.lggr.Infof("accepting proposal %s for state %s", proposalID, p.stateID)
+	return errors.Wrap(p.send(ctx, []solana.Instruction{accept.Build()}), "error in Accept.AcceptProposal")
+}
+
+// Close closes an abandoned proposal and returns its rent to the payer
+func (p *Proposer) Close(ctx context.Context, proposalID solana.PublicKey) error {
+	p.lggr.Infof("closing proposal %s", proposalID)
+	return errors.Wrap(p.send(ctx, []solana.Instruction{
+		ocr_2.NewCloseProposalInstruction(proposalID, p.payer.PublicKey(), p.authority.PublicKey()).Build(),
+	}), "error in Close.CloseProposal")
+}
+
+// ReadProposal fetches and decodes a proposal account, returning rpc.ErrNotFound if it does not exist
+func (p *Proposer) ReadProposal(ctx context.Context, proposalID solana.PublicKey) (ocr_2.Proposal, error) {
+	res, err := p.client.GetAccountInfoWithOpts(ctx, proposalID, &rpc.GetAccountInfoOpts{
+		Commitment: p.commitment,
+		Encoding:   "base64",
+	})
+	if err != nil {
+		return ocr_2.Proposal{}, err
+	}
+	if res == nil || res.Value == nil || res.Value.Data == nil {
+		return ocr_2.Proposal{}, rpc.ErrNotFound
+	}
+	if !res.Value.Owner.Equals(p.programID) {
+		return ocr_2.Proposal{}, fmt.Errorf("proposal %s is owned by program %s, not %s", proposalID, res.Value.Owner, p.programID)
+	}
+	var proposal ocr_2.Proposal
+	if err = bin.NewBinDecoder(res.Value.Data.GetBinary()).Decode(&proposal); err != nil {
+		return ocr_2.Proposal{}, fmt.Errorf("failed to decode proposal account data: %w", err)
+	}
+	return proposal, nil
+}
+
+func (p *Proposer) create(ctx context.Context, proposal Signer, version uint64) error {
+	rent, err := p.client.GetMinimumBalanceForRentExemption(ctx, AccountSize, p.commitment)
+	if err != nil {
+		return errors.Wrap(err, "error in create.GetMinimumBalanceForRentExemption")
+	}
+	return errors.Wrap(p.send(ctx, []solana.Instruction{
+		system.NewCreateAccountInstruction(rent, AccountSize, p.programID, p.payer.PublicKey(), proposal.PublicKey()).Build(),
+		ocr_2.NewCreateProposalInstruction(version, proposal.PublicKey(), p.authority.PublicKey()).Build(),
+	}, proposal), "error in create.CreateProposal")
+}
+
+// send signs the instructions with the payer, authority and extra signers, then waits for confirmation
+func (p *Proposer) send(ctx context.Context, instructions []solana.Instruction, extra ...Signer) error {
+	// generated instructions use the package level program ID, bind them to the proposer program
+	for i, ix := range instructions {
+		if _, ok := ix.(*ocr_2.Instruction); !ok {
+			continue
+		}
+		data, err := ix.Data()
+		if err != nil {
+			return err
+		}
+		instructions[i] = solana.NewInstruction(p.programID, ix.Accounts(), data)
+	}
+
+	blockhash, err := p.client.GetLatestBlockhash(ctx, p.commitment)
+	if err != nil {
+		return errors.Wrap(err, "error in send.GetLatestBlockhash")
+	}
+	if blockhash == nil || blockhash.Value == nil {
+		return errors.New("nil pointer returned from send.GetLatestBlockhash")
+	}
+	tx, err := solana.NewTransaction(instructions, blockhash.Value.Blockhash, solana.TransactionPayer(p.payer.PublicKey()))
+	if err != nil {
+		return errors.Wrap(err, "error in send.NewTransaction")
+	}
+
+	signers := map[solana.PublicKey]Signer{}
+	for _, s := range append([]Signer{p.payer, p.authority}, extra...) {
+		signers[s.PublicKey()] = s
+	}
+	msg, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return errors.Wrap(err, "error in send.MarshalBinary")
+	}
+	for _, key := range tx.Message.AccountKeys[:tx.Message.Header.NumRequiredSignatures] {
+		s, ok := signers[key]
+		if !ok {
+			return fmt.Errorf("missing signer for %s", key)
+		}
+		sig, err := s.Sign(msg)
+		if err != nil {
+			return errors.Wrap(err, "error in send.Sign")
+		}
+		tx.Signatures = append(tx.Signatures, solana.SignatureFromBytes(sig))
+	}
+
+	txSig, err := p.client.SendTransactionWithOpts(ctx, tx, false, p.commitment)
+	if err != nil {
+		return errors.Wrap(err, "error in send.SendTransactionWithOpts")
+	}
+	return p.confirm(ctx, txSig)
+}
+
+func (p *Proposer) confirm(ctx context.Context, txSig solana.Signature) error {
+	ctx, cancel := context.WithTimeout(ctx, p.txTimeout)
+	defer cancel()
+	tick := time.NewTicker(p.pollPeriod)
+	defer tick.Stop()
+	for {
+		res, err := p.client.GetSignatureStatuses(ctx, false, txSig)
+		if err == nil && res != nil && len(res.Value) == 1 && res.Value[0] != nil {
+			status := res.Value[0]
+			if status.Err != nil {
+				return fmt.Errorf("transaction %s failed: %v", txSig, status.Err)
+			}
+			if confirmed(status.ConfirmationStatus, p.commitment) {
+				return nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transaction %s not confirmed: %w", txSig, ctx.Err())
+		case <-tick.C:
+		}
+	}
+}
+
+// confirmed returns true if status reached the commitment level
+func confirmed(status rpc.ConfirmationStatusType, commitment rpc.CommitmentType) bool {
+	switch commitment {
+	case rpc.CommitmentFinalized:
+		return status == rpc.ConfirmationStatusFinalized
+	case rpc.CommitmentConfirmed:
+		return status == rpc.ConfirmationStatusConfirmed || status == rpc.ConfirmationStatusFinalized
+	default:
+		return status != ""
+	}
+}
+
+func oraclesMatch(p ocr_2.Proposal, oracles []Oracle, f uint8) bool {
+	if p.F != f || p.Oracles.Len != uint64(len(oracles)) {
+		return false
+	}
+	for i, o := range oracles {
+		if p.Oracles.Xs[i].Signer.Key != o.Signer || !p.Oracles.Xs[i].Transmitter.Equals(o.Transmitter) {
+			return false
+		}
+	}
+	return true
+}
+
+func payeesMatch(p ocr_2.Proposal, oracles []Oracle, mint solana.PublicKey) bool {
+	if !p.TokenMint.Equals(mint) || p.Oracles.Len != uint64(len(oracles)) {
+		return false
+	}
+	for i, o := range oracles {
+		if !p.Oracles.Xs[i].Payee.Equals(o.Payee) {
+			return false
+		}
+	}
+	return true
+}
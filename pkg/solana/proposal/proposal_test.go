@@ -0,0 +1,429 @@
+package proposal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
+	"github.com/smartcontractkit/chainlink/core/logger"
+)
+
+type keySigner struct {
+	key solana.PrivateKey
+}
+
+func newKeySigner() keySigner {
+	return keySigner{key: solana.NewWallet().PrivateKey}
+}
+
+func (s keySigner) PublicKey() solana.PublicKey {
+	return s.key.PublicKey()
+}
+
+func (s keySigner) Sign(msg []byte) ([]byte, error) {
+	sig, err := s.key.Sign(msg)
+	return sig[:], err
+}
+
+// testProgram is an in-memory OCR2 program applying proposal instructions
+type testProgram struct {
+	lock      sync.Mutex
+	programID solana.PublicKey
+	stateID   solana.PublicKey
+	state     relaySol.State
+	proposals map[solana.PublicKey]*ocr_2.Proposal
+	sent      []*ocr_2.Instruction
+	failAfter int // fail sent transactions once failAfter instructions were applied, disabled if negative
+	accepted  []solana.PublicKey
+}
+
+func newTestProgram() *testProgram {
+	p := &testProgram{
+		programID: solana.NewWallet().PublicKey(),
+		stateID:   solana.NewWallet().PublicKey(),
+		proposals: map[solana.PublicKey]*ocr_2.Proposal{},
+		failAfter: -1,
+	}
+	p.state.AccountDiscriminator = ocr_2.StateDiscriminator
+	p.state.Version = 1
+	return p
+}
+
+func (p *testProgram) GetAccountInfoWithOpts(_ context.Context, account solana.PublicKey, _ *rpc.GetAccountInfoOpts) (*rpc.GetAccountInfoResult, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var buf bytes.Buffer
+	switch {
+	case account.Equals(p.stateID):
+		if err := bin.NewBinEncoder(&buf).Encode(p.state); err != nil {
+			return nil, err
+		}
+	case p.proposals[account] != nil:
+		if err := bin.NewBinEncoder(&buf).Encode(*p.proposals[account]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, rpc.ErrNotFound
+	}
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.GetAccountInfoResult{Value: &rpc.Account{Owner: p.programID, Data: data}}, nil
+}
+
+func (p *testProgram) GetMinimumBalanceForRentExemption(context.Context, uint64, rpc.CommitmentType) (uint64, error) {
+	return 1, nil
+}
+
+func (p *testProgram) GetLatestBlockhash(context.Context, rpc.CommitmentType) (*rpc.GetLatestBlockhashResult, error) {
+	return &rpc.GetLatestBlockhashResult{Value: &rpc.LatestBlockhashResult{Blockhash: solana.Hash{1}}}, nil
+}
+
+func (p *testProgram) SendTransactionWithOpts(_ context.Context, tx *solana.Transaction, _ bool, _ rpc.CommitmentType) (solana.Signature, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := tx.VerifySignatures(); err != nil {
+		return solana.Signature{}, err
+	}
+	if err := relaySol.CheckTxSize(tx.Message); err != nil {
+		return solana.Signature{}, err
+	}
+	for _, ci := range tx.Message.Instructions {
+		programID := tx.Message.AccountKeys[ci.ProgramIDIndex]
+		accounts := ci.ResolveInstructionAccounts(&tx.Message)
+		if programID.Equals(solana.SystemProgramID) {
+			// create_account
+			p.proposals[accounts[1].PublicKey] = &ocr_2.Proposal{}
+			continue
+		}
+		if !programID.Equals(p.programID) {
+			return solana.Signature{}, errors.New("unexpected program")
+		}
+		if p.failAfter == 0 {
+			return solana.Signature{}, errors.New("interrupted")
+		}
+		p.failAfter--
+		ix, err := ocr_2.DecodeInstruction(accounts, ci.Data)
+		if err != nil {
+			return solana.Signature{}, err
+		}
+		if err = p.apply(ix, accounts); err != nil {
+			return solana.Signature{}, err
+		}
+		p.sent = append(p.sent, ix)
+	}
+	return tx.Signatures[0], nil
+}
+
+func (p *testProgram) apply(ix *ocr_2.Instruction, accounts []*solana.AccountMeta) error {
+	proposal := p.proposals[accounts[0].PublicKey]
+	if _, ok := ix.Impl.(*ocr_2.AcceptProposal); ok {
+		proposal = p.proposals[accounts[1].PublicKey]
+	}
+	if proposal == nil {
+		return errors.New("missing proposal")
+	}
+	switch ix.Impl.(type) {
+	case *ocr_2.AcceptProposal, *ocr_2.CloseProposal:
+	default:
+		if proposal.State == stateFinalized {
+			return errors.New("proposal finalized")
+		}
+	}
+	switch inst := ix.Impl.(type) {
+	case *ocr_2.CreateProposal:
+		proposal.Owner = accounts[1].PublicKey
+		proposal.Version = 1
+		proposal.OffchainConfig.Version = *inst.OffchainConfigVersion
+	case *ocr_2.ProposeConfig:
+		proposal.F = *inst.F
+		proposal.Oracles = ocr_2.ProposedOracles{}
+		for i, o := range *inst.NewOracles {
+			proposal.Oracles.Xs[i] = ocr_2.ProposedOracle{Transmitter: o.Transmitter, Signer: ocr_2.SigningKey{Key: o.Signer}}
+		}
+		proposal.Oracles.Len = uint64(len(*inst.NewOracles))
+	case *ocr_2.ProposePayees:
+		proposal.TokenMint = *inst.TokenMint
+		for i, payee := range *inst.Payees {
+			proposal.Oracles.Xs[i].Payee = payee
+		}
+	case *ocr_2.WriteOffchainConfig:
+		n := copy(proposal.OffchainConfig.Xs[proposal.OffchainConfig.Len:], *inst.OffchainConfig)
+		proposal.OffchainConfig.Len += uint64(n)
+	case *ocr_2.FinalizeProposal:
+		proposal.State = stateFinalized
+	case *ocr_2.AcceptProposal:
+		digest, err := relaySol.ProposalDigest(*proposal)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(digest, *inst.Digest) {
+			return errors.New("digest mismatch")
+		}
+		if len(accounts) != 7+int(p.state.Oracles.Len) {
+			return errors.New("missing oracle payees")
+		}
+		// the proposed config replaces the state config
+		p.state.Config.F = proposal.F
+		p.state.Config.TokenMint = proposal.TokenMint
+		p.state.Oracles = relaySol.Oracles{Len: proposal.Oracles.Len}
+		for i, o := range proposal.Oracles.Xs[:proposal.Oracles.Len] {
+			p.state.Oracles.Raw[i] = relaySol.Oracle{Transmitter: o.Transmitter, Signer: relaySol.SigningKey{Key: o.Signer.Key}, Payee: o.Payee}
+		}
+		p.state.OffchainConfig = relaySol.OffchainConfig{Version: proposal.OffchainConfig.Version, Len: proposal.OffchainConfig.Len}
+		copy(p.state.OffchainConfig.Raw[:], proposal.OffchainConfig.Xs[:proposal.OffchainConfig.Len])
+		p.accepted = append(p.accepted, accounts[1].PublicKey)
+		delete(p.proposals, accounts[1].PublicKey)
+	case *ocr_2.CloseProposal:
+		delete(p.proposals, accounts[0].PublicKey)
+	default:
+		return errors.New("unexpected instruction")
+	}
+	return nil
+}
+
+func (p *testProgram) GetSignatureStatuses(context.Context, bool, ...solana.Signature) (*rpc.GetSignatureStatusesResult, error) {
+	return &rpc.GetSignatureStatusesResult{Value: []*rpc.SignatureStatusesResult{
+		{ConfirmationStatus: rpc.ConfirmationStatusConfirmed},
+	}}, nil
+}
+
+func testConfig(n int, offchainLen int) Config {
+	cfg := Config{
+		F:                     1,
+		TokenMint:             solana.NewWallet().PublicKey(),
+		OffchainConfigVersion: 2,
+		OffchainConfig:        make([]byte, offchainLen),
+	}
+	for i := range cfg.OffchainConfig {
+		cfg.OffchainConfig[i] = byte(i)
+	}
+	for i := 0; i < n; i++ {
+		// signers in reverse order to exercise sorting
+		cfg.Oracles = append(cfg.Oracles, Oracle{
+			Signer:      [20]byte{byte(n - i)},
+			Transmitter: solana.NewWallet().PublicKey(),
+			Payee:       solana.NewWallet().PublicKey(),
+		})
+	}
+	return cfg
+}
+
+func testProposer(t *testing.T, program *testProgram) *Proposer {
+	p := NewProposer(program, program.programID, program.stateID, newKeySigner(), newKeySigner(), logger.TestLogger(t))
+	p.pollPeriod = time.Millisecond
+	return p
+}
+
+func countInstructions(ixs []*ocr_2.Instruction) map[string]int {
+	counts := map[string]int{}
+	for _, ix := range ixs {
+		counts[ocr_2.InstructionIDToName(ix.TypeID)]++
+	}
+	return counts
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, testConfig(4, 10).Validate())
+
+	for name, modify := range map[string]func(*Config){
+		"f zero":             func(c *Config) { c.F = 0 },
+		"f too large":        func(c *Config) { c.F = 2 },
+		"too many oracles":   func(c *Config) { *c = testConfig(relaySol.MaxOracles+1, 10) },
+		"duplicate signer":   func(c *Config) { c.Oracles[1].Signer = c.Oracles[0].Signer },
+		"duplicate transmit": func(c *Config) { c.Oracles[1].Transmitter = c.Oracles[0].Transmitter },
+		"missing payee":      func(c *Config) { c.Oracles[0].Payee = solana.PublicKey{} },
+		"missing version":    func(c *Config) { c.OffchainConfigVersion = 0 },
+		"empty offchain":     func(c *Config) { c.OffchainConfig = nil },
+		"offchain too large": func(c *Config) { c.OffchainConfig = make([]byte, relaySol.MaxOffchainConfigLen) },
+	} {
+		cfg := testConfig(4, 10)
+		modify(&cfg)
+		assert.Error(t, cfg.Validate(), name)
+	}
+}
+
+func TestDigest(t *testing.T) {
+	cfg := testConfig(4, 10)
+	digest := Digest(cfg)
+	assert.Len(t, digest, 32)
+
+	// oracle order does not matter, the program sorts them by signer
+	reversed := cfg
+	reversed.Oracles = nil
+	for i := len(cfg.Oracles) - 1; i >= 0; i-- {
+		reversed.Oracles = append(reversed.Oracles, cfg.Oracles[i])
+	}
+	assert.Equal(t, digest, Digest(reversed))
+
+	changed := cfg
+	changed.OffchainConfig = append([]byte{}, cfg.OffchainConfig...)
+	changed.OffchainConfig[0]++
+	assert.NotEqual(t, digest, Digest(changed))
+}
+
+func TestProposer_Run(t *testing.T) {
+	program := newTestProgram()
+	p := testProposer(t, program)
+	// current oracles are paid out on accept
+	program.state.Oracles.Len = 2
+	program.state.Oracles.Raw[0].Payee = solana.NewWallet().PublicKey()
+	program.state.Oracles.Raw[1].Payee = solana.NewWallet().PublicKey()
+
+	chunkLen, err := relaySol.OffchainConfigChunkLen()
+	require.NoError(t, err)
+	proposal := newKeySigner()
+	cfg := testConfig(4, 2*chunkLen+1)
+	require.NoError(t, p.Run(context.Background(), proposal, cfg))
+
+	assert.Equal(t, map[string]int{
+		"CreateProposal":      1,
+		"ProposeConfig":       1,
+		"ProposePayees":       1,
+		"WriteOffchainConfig": 3,
+		"FinalizeProposal":    1,
+		"AcceptProposal":      1,
+	}, countInstructions(program.sent))
+	assert.Equal(t, []solana.PublicKey{proposal.PublicKey()}, program.accepted)
+	assert.Empty(t, program.proposals)
+
+	// resuming after the accept landed does not propose the config again
+	program.sent = nil
+	_, err = p.Propose(context.Background(), proposal, cfg)
+	assert.ErrorIs(t, err, ErrAccepted)
+	require.NoError(t, p.Run(context.Background(), proposal, cfg))
+	assert.Empty(t, program.sent)
+	assert.Len(t, program.accepted, 1)
+
+	// a different config is proposed in a new account
+	changed := testConfig(4, 10)
+	require.NoError(t, p.Run(context.Background(), proposal, changed))
+	assert.Len(t, program.accepted, 2)
+}
+
+func TestProposer_OffchainConfigLenOutOfRange(t *testing.T) {
+	program := newTestProgram()
+	p := testProposer(t, program)
+	proposal := newKeySigner()
+	cfg := testConfig(4, 10)
+
+	program.failAfter = 3
+	_, err := p.Propose(context.Background(), proposal, cfg)
+	require.Error(t, err)
+	program.proposals[proposal.PublicKey()].OffchainConfig.Len = relaySol.MaxOffchainConfigLen + 1
+
+	program.failAfter = -1
+	_, err = p.Propose(context.Background(), proposal, cfg)
+	assert.ErrorContains(t, err, "exceeds")
+}
+
+func TestProposer_Resume(t *testing.T) {
+	program := newTestProgram()
+	p := testProposer(t, program)
+	chunkLen, err := relaySol.OffchainConfigChunkLen()
+	require.NoError(t, err)
+	proposal := newKeySigner()
+	cfg := testConfig(4, 2*chunkLen+1)
+
+	// interrupted after the first offchain config chunk
+	program.failAfter = 4
+	_, err = p.Propose(context.Background(), proposal, cfg)
+	require.Error(t, err)
+	current, err := p.ReadProposal(context.Background(), proposal.PublicKey())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(chunkLen), current.OffchainConfig.Len)
+
+	// resumed without repeating completed steps
+	program.failAfter = -1
+	program.sent = nil
+	digest, err := p.Propose(context.Background(), proposal, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Digest(cfg), digest)
+	assert.Equal(t, map[string]int{
+		"WriteOffchainConfig": 2,
+		"FinalizeProposal":    1,
+	}, countInstructions(program.sent))
+
+	// finalized proposal with the same config is a no-op
+	program.sent = nil
+	digest, err = p.Propose(context.Background(), proposal, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Digest(cfg), digest)
+	assert.Empty(t, program.sent)
+
+	// finalized proposal with a different config can't be resumed
+	changed := cfg
+	changed.F = 1
+	changed.Oracles = append([]Oracle{}, cfg.Oracles[1:]...)
+	_, err = p.Propose(context.Background(), proposal, changed)
+	assert.Error(t, err)
+
+	// accept fails on digest mismatch
+	assert.Error(t, p.Accept(context.Background(), proposal.PublicKey(), Digest(changed)))
+}
+
+func TestProposer_ResumeChangedOracles(t *testing.T) {
+	program := newTestProgram()
+	p := testProposer(t, program)
+	proposal := newKeySigner()
+	cfg := testConfig(4, 10)
+
+	// interrupted after proposing payees
+	program.failAfter = 3
+	_, err := p.Propose(context.Background(), proposal, cfg)
+	require.Error(t, err)
+
+	// oracles changed before resuming are re-proposed along with their payees
+	cfg.Oracles[0].Transmitter = solana.NewWallet().PublicKey()
+	program.failAfter = -1
+	program.sent = nil
+	digest, err := p.Propose(context.Background(), proposal, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Digest(cfg), digest)
+	assert.Equal(t, map[string]int{
+		"ProposeConfig":       1,
+		"ProposePayees":       1,
+		"WriteOffchainConfig": 1,
+		"FinalizeProposal":    1,
+	}, countInstructions(program.sent))
+
+	// offchain config that is not an extension of what was written can't be resumed
+	proposal = newKeySigner()
+	program.failAfter = 4
+	_, err = p.Propose(context.Background(), proposal, cfg)
+	require.Error(t, err)
+	program.failAfter = -1
+	cfg.OffchainConfig[0]++
+	_, err = p.Propose(context.Background(), proposal, cfg)
+	assert.Error(t, err)
+}
+
+func TestProposer_Close(t *testing.T) {
+	program := newTestProgram()
+	p := testProposer(t, program)
+	proposal := newKeySigner()
+
+	program.failAfter = 2
+	_, err := p.Propose(context.Background(), proposal, testConfig(4, 10))
+	require.Error(t, err)
+	require.Contains(t, program.proposals, proposal.PublicKey())
+
+	program.failAfter = -1
+	require.NoError(t, p.Close(context.Background(), proposal.PublicKey()))
+	assert.Empty(t, program.proposals)
+	_, err = p.ReadProposal(context.Background(), proposal.PublicKey())
+	assert.True(t, errors.Is(err, rpc.ErrNotFound))
+}
@@ -0,0 +1,64 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+)
+
+// ProposalOracle is an oracle of a config proposal
+type ProposalOracle struct {
+	Signer      [20]byte
+	Transmitter solana.PublicKey
+	Payee       solana.PublicKey
+}
+
+// ProposalDigest returns the digest of an on-chain proposal, matching Proposal.digest() in the OCR2 program.
+// This is the digest accept_proposal must be called with.
+func ProposalDigest(p ocr_2.Proposal) ([]byte, error) {
+	if p.Oracles.Len > uint64(len(p.Oracles.Xs)) {
+		return nil, fmt.Errorf("proposal oracles length %d exceeds %d", p.Oracles.Len, len(p.Oracles.Xs))
+	}
+	if p.OffchainConfig.Len > uint64(len(p.OffchainConfig.Xs)) {
+		return nil, fmt.Errorf("proposal offchain config length %d exceeds %d", p.OffchainConfig.Len, len(p.OffchainConfig.Xs))
+	}
+	oracles := make([]ProposalOracle, p.Oracles.Len)
+	for i, o := range p.Oracles.Xs[:p.Oracles.Len] {
+		oracles[i] = ProposalOracle{Signer: o.Signer.Key, Transmitter: o.Transmitter, Payee: o.Payee}
+	}
+	return proposalDigest(oracles, p.F, p.TokenMint, p.OffchainConfig.Version, p.OffchainConfig.Xs[:p.OffchainConfig.Len]), nil
+}
+
+// ProposalDigestFromConfig returns the digest of a proposal holding the given config once finalized.
+// Oracles are sorted by signer as done by propose_config, so their order does not matter.
+func ProposalDigestFromConfig(oracles []ProposalOracle, f uint8, tokenMint solana.PublicKey, offchainConfigVersion uint64, offchainConfig []byte) []byte {
+	sorted := append([]ProposalOracle{}, oracles...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Signer[:], sorted[j].Signer[:]) < 0
+	})
+	return proposalDigest(sorted, f, tokenMint, offchainConfigVersion, offchainConfig)
+}
+
+func proposalDigest(oracles []ProposalOracle, f uint8, tokenMint solana.PublicKey, offchainConfigVersion uint64, offchainConfig []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{uint8(len(oracles))})
+	for _, o := range oracles {
+		h.Write(o.Signer[:])
+		h.Write(o.Transmitter.Bytes())
+		h.Write(o.Payee.Bytes())
+	}
+	h.Write([]byte{f})
+	h.Write(tokenMint.Bytes())
+	header := make([]byte, 8+4)
+	binary.BigEndian.PutUint64(header, offchainConfigVersion)
+	binary.BigEndian.PutUint32(header[8:], uint32(len(offchainConfig)))
+	h.Write(header)
+	h.Write(offchainConfig)
+	return h.Sum(nil)
+}
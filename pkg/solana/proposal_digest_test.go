@@ -0,0 +1,52 @@
+package solana
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+)
+
+func TestProposalDigest(t *testing.T) {
+	oracles := []ProposalOracle{
+		{Signer: [20]byte{2}, Transmitter: solana.PublicKey{3}, Payee: solana.PublicKey{4}},
+		{Signer: [20]byte{1}, Transmitter: solana.PublicKey{5}, Payee: solana.PublicKey{6}},
+	}
+	mint := solana.PublicKey{7}
+	offchainConfig := []byte{8, 9, 10}
+
+	digest := ProposalDigestFromConfig(oracles, 1, mint, 2, offchainConfig)
+	assert.Equal(t, "93d3ee0e4efd8cae32072489084ed9558675f567ac066bc514e9b471a760345d", hex.EncodeToString(digest))
+
+	// on-chain proposal holds the oracles sorted by signer
+	var p ocr_2.Proposal
+	p.F = 1
+	p.TokenMint = mint
+	p.Oracles.Len = 2
+	p.Oracles.Xs[0] = ocr_2.ProposedOracle{Signer: ocr_2.SigningKey{Key: oracles[1].Signer}, Transmitter: oracles[1].Transmitter, Payee: oracles[1].Payee}
+	p.Oracles.Xs[1] = ocr_2.ProposedOracle{Signer: ocr_2.SigningKey{Key: oracles[0].Signer}, Transmitter: oracles[0].Transmitter, Payee: oracles[0].Payee}
+	p.OffchainConfig.Version = 2
+	p.OffchainConfig.Len = uint64(copy(p.OffchainConfig.Xs[:], offchainConfig))
+	proposalDigest, err := ProposalDigest(p)
+	require.NoError(t, err)
+	assert.Equal(t, digest, proposalDigest)
+
+	// any change is reflected in the digest
+	p.Oracles.Xs[0].Payee = solana.PublicKey{11}
+	proposalDigest, err = ProposalDigest(p)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, proposalDigest)
+
+	// invalid lengths
+	p.Oracles.Len = uint64(len(p.Oracles.Xs) + 1)
+	_, err = ProposalDigest(p)
+	assert.Error(t, err)
+	p.Oracles.Len = 2
+	p.OffchainConfig.Len = uint64(len(p.OffchainConfig.Xs) + 1)
+	_, err = ProposalDigest(p)
+	assert.Error(t, err)
+}
@@ -2,12 +2,18 @@ package solana
 
 import (
 	"context"
+	"sync"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/pkg/errors"
 	uuid "github.com/satori/go.uuid"
+	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/audit"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
 	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/monitor"
 	relaytypes "github.com/smartcontractkit/chainlink/core/services/relay/types"
 	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
 	"github.com/smartcontractkit/libocr/offchainreporting2/types"
@@ -36,6 +42,9 @@ type OCR2Spec struct {
 	TransmissionsID solana.PublicKey
 
 	TransmissionSigner TransmissionSigner
+
+	// optional log of every Transmit call
+	AuditLog *audit.Log
 }
 
 type Relayer struct {
@@ -43,6 +52,28 @@ type Relayer struct {
 	chainSet ChainSet
 	ctx      context.Context
 	cancel   func()
+
+	// transmitter balance monitors, one per chain
+	balanceMonitors map[string]*monitor.BalanceMonitor
+	monitorsLock    sync.Mutex
+
+	// state pollers shared by the jobs watching the same feed
+	pollers     map[pollerKey]*sharedPoller
+	pollersLock sync.Mutex
+}
+
+// pollerKey identifies the accounts polled for a feed on a chain
+type pollerKey struct {
+	chainID         string
+	programID       solana.PublicKey
+	stateID         solana.PublicKey
+	storeProgramID  solana.PublicKey
+	transmissionsID solana.PublicKey
+}
+
+type sharedPoller struct {
+	poller *feedPoller
+	refs   int
 }
 
 // Note: constructed in core
@@ -53,6 +84,9 @@ func NewRelayer(lggr logger.Logger, chainSet ChainSet) *Relayer {
 		chainSet: chainSet,
 		ctx:      ctx,
 		cancel:   cancel,
+
+		balanceMonitors: map[string]*monitor.BalanceMonitor{},
+		pollers:         map[pollerKey]*sharedPoller{},
 	}
 }
 
@@ -68,7 +102,14 @@ func (r *Relayer) Start(context.Context) error {
 // Close will close all open subservices
 func (r *Relayer) Close() error {
 	r.cancel()
-	return nil
+
+	r.monitorsLock.Lock()
+	defer r.monitorsLock.Unlock()
+	var err error
+	for _, m := range r.balanceMonitors {
+		err = multierr.Append(err, m.Close())
+	}
+	return err
 }
 
 func (r *Relayer) Ready() error {
@@ -77,7 +118,65 @@ func (r *Relayer) Ready() error {
 
 // Healthy only if all subservices are healthy
 func (r *Relayer) Healthy() error {
-	return r.chainSet.Healthy()
+	if err := r.chainSet.Healthy(); err != nil {
+		return err
+	}
+
+	r.monitorsLock.Lock()
+	defer r.monitorsLock.Unlock()
+	var err error
+	for _, m := range r.balanceMonitors {
+		err = multierr.Append(err, m.Healthy())
+	}
+	return err
+}
+
+// trackBalance adds the transmitter to the balance monitor of the chain, starting the monitor for the first job
+func (r *Relayer) trackBalance(chain Chain, transmitter solana.PublicKey) (untrack func(), err error) {
+	r.monitorsLock.Lock()
+	defer r.monitorsLock.Unlock()
+	m, ok := r.balanceMonitors[chain.ID()]
+	if !ok {
+		m = monitor.NewBalanceMonitor(chain.ID(), chain.Config(), r.lggr, chain.Reader)
+		if err := m.Start(r.ctx); err != nil {
+			return nil, errors.Wrap(err, "error in trackBalance.BalanceMonitor.Start")
+		}
+		r.balanceMonitors[chain.ID()] = m
+	}
+	return m.Track(transmitter), nil
+}
+
+// acquirePoller returns the poller of the feed watched by spec, creating it for the first job.
+// The poller is stopped and dropped once release was called for every job.
+func (r *Relayer) acquirePoller(spec OCR2Spec, cfg config.Config, reader client.Reader) (poller *feedPoller, release func()) {
+	key := pollerKey{
+		chainID:         spec.ChainID,
+		programID:       spec.ProgramID,
+		stateID:         spec.StateID,
+		storeProgramID:  spec.StoreProgramID,
+		transmissionsID: spec.TransmissionsID,
+	}
+	r.pollersLock.Lock()
+	defer r.pollersLock.Unlock()
+	shared, ok := r.pollers[key]
+	if !ok {
+		shared = &sharedPoller{poller: newFeedPoller(spec, cfg, reader, r.lggr)}
+		r.pollers[key] = shared
+	}
+	shared.refs++
+
+	var once sync.Once
+	return shared.poller, func() {
+		once.Do(func() {
+			r.pollersLock.Lock()
+			defer r.pollersLock.Unlock()
+			if shared.refs--; shared.refs == 0 {
+				// stopped under the lock, a poller acquired next for the feed never overlaps this one
+				shared.poller.stop()
+				delete(r.pollers, key)
+			}
+		})
+	}
 }
 
 // NewOCR2Provider creates a new OCR2ProviderCtx instance.
@@ -104,30 +203,43 @@ func (r *Relayer) NewOCR2Provider(externalJobID uuid.UUID, s interface{}) (relay
 	msgEnqueuer := chain.TxManager()
 	cfg := chain.Config()
 
-	// provide contract config + tracker reader + tx manager + signer + logger
-	contractTracker := NewTracker(spec, cfg, chainReader, msgEnqueuer, spec.TransmissionSigner, r.lggr)
+	// provide contract config + tracker reader + tx manager + signer + logger, polling is shared with other jobs of the feed
+	poller, releasePoller := r.acquirePoller(spec, cfg, chainReader)
+	contractTracker := newTracker(spec, poller, cfg, chainReader, msgEnqueuer, spec.TransmissionSigner, r.lggr)
 
 	if spec.IsBootstrap {
 		// Return early if bootstrap node (doesn't require the full OCR2 provider)
 		return &ocr2Provider{
 			offchainConfigDigester: offchainConfigDigester,
 			tracker:                &contractTracker,
+			releasePoller:          releasePoller,
 		}, nil
 	}
 
-	reportCodec := ReportCodec{}
+	// report layout follows the program version of the tracked state
+	reportCodec := NewVersionedReportCodec(contractTracker.ReportVersion)
+
+	untrackBalance, err := r.trackBalance(chain, spec.TransmissionSigner.PublicKey())
+	if err != nil {
+		releasePoller()
+		return nil, errors.Wrap(err, "error in NewOCR2Provider.trackBalance")
+	}
 
 	return &ocr2Provider{
 		offchainConfigDigester: offchainConfigDigester,
 		reportCodec:            reportCodec,
 		tracker:                &contractTracker,
+		untrackBalance:         untrackBalance,
+		releasePoller:          releasePoller,
 	}, nil
 }
 
 type ocr2Provider struct {
 	offchainConfigDigester OffchainConfigDigester
-	reportCodec            ReportCodec
+	reportCodec            median.ReportCodec
 	tracker                *ContractTracker
+	untrackBalance         func() // nil for bootstrap providers
+	releasePoller          func()
 }
 
 // Start starts OCR2Provider respecting the given context.
@@ -138,7 +250,12 @@ func (p *ocr2Provider) Start(context.Context) error {
 
 func (p *ocr2Provider) Close() error {
 	// TODO: close all subservices
-	return p.tracker.Close()
+	if p.untrackBalance != nil {
+		p.untrackBalance()
+	}
+	err := p.tracker.Close()
+	p.releasePoller()
+	return err
 }
 
 func (p ocr2Provider) Ready() error {
@@ -1,7 +1,6 @@
 package solana
 
 import (
-	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"math/big"
@@ -51,27 +50,76 @@ func (c ReportCodec) BuildReport(oo []median.ParsedAttributedObservation) (types
 		observers[i] = byte(o.Observer)
 	}
 
-	// report encoding
-	report := []byte{}
+	return EncodeReport(Report{
+		Timestamp:       timestamp,
+		ObserversCount:  uint8(n),
+		Observers:       observers,
+		Median:          median,
+		JuelsPerFeeCoin: juelsPerFeeCoin,
+	})
+}
+
+func (c ReportCodec) MedianFromReport(report types.Report) (*big.Int, error) {
+	// report should contain timestamp + observers + median + juels per eth
+	if len(report) != int(ReportLen) {
+		return nil, fmt.Errorf("report length missmatch: %d (received), %d (expected)", len(report), ReportLen)
+	}
+
+	// unpack median observation
+	start := int(ReportHeaderLen)
+	end := start + int(MedianLen)
+	median := report[start:end]
+	return bigbigendian.DeserializeSigned(int(MedianLen), median)
+}
+
+// Report is a decoded median report
+type Report struct {
+	Version         ReportVersion // ReportV1 if unset
+	Timestamp       uint32
+	ObserversCount  uint8
+	Observers       [32]uint8 // only the first ObserversCount entries are set
+	Median          *big.Int
+	JuelsPerFeeCoin *big.Int
+}
+
+// EncodeReport serializes a report with the layout of its version
+func EncodeReport(r Report) (types.Report, error) {
+	if int(r.ObserversCount) > len(r.Observers) {
+		return nil, fmt.Errorf("observers count %d exceeds maximum of %d", r.ObserversCount, len(r.Observers))
+	}
+	if r.Median == nil || r.JuelsPerFeeCoin == nil {
+		return nil, fmt.Errorf("report median and juelsPerFeeCoin must be set")
+	}
+	switch r.Version {
+	case 0, ReportV1:
+		return encodeReportV1(r)
+	default:
+		return nil, fmt.Errorf("unsupported report version %d", r.Version)
+	}
+}
+
+// encodeReportV1 serializes a v1 report:
+// timestamp (uint32) + observers count (uint8) + observers [32]uint8 + median (int128) + juelsPerFeeCoin (int64), big endian
+func encodeReportV1(r Report) (types.Report, error) {
+	report := make([]byte, 0, ReportLen)
 
 	time := make([]byte, 4)
-	binary.BigEndian.PutUint32(time, timestamp)
+	binary.BigEndian.PutUint32(time, r.Timestamp)
 	report = append(report, time[:]...)
 
-	observersCount := uint8(n)
-	report = append(report, observersCount)
+	report = append(report, r.ObserversCount)
 
-	report = append(report, observers[:]...)
+	report = append(report, r.Observers[:]...)
 
 	// TODO: replace with generalized function from libocr
-	medianBytes, err := bigbigendian.SerializeSigned(int(MedianLen), median)
+	medianBytes, err := bigbigendian.SerializeSigned(int(MedianLen), r.Median)
 	if err != nil {
 		return nil, fmt.Errorf("error in SerializeSigned(median): %w", err)
 	}
 	report = append(report, medianBytes[:]...)
 
 	// TODO: replace with generalized function from libocr
-	juelsPerFeeCoinBytes, err := bigbigendian.SerializeSigned(int(JuelsLen), juelsPerFeeCoin)
+	juelsPerFeeCoinBytes, err := bigbigendian.SerializeSigned(int(JuelsLen), r.JuelsPerFeeCoin)
 	if err != nil {
 		return nil, fmt.Errorf("error in SerializeSigned(juelsPerFeeCoin): %w", err)
 	}
@@ -80,34 +128,60 @@ func (c ReportCodec) BuildReport(oo []median.ParsedAttributedObservation) (types
 	return types.Report(report), nil
 }
 
-func (c ReportCodec) MedianFromReport(report types.Report) (*big.Int, error) {
-	// report should contain timestamp + observers + median + juels per eth
-	if len(report) != int(ReportLen) {
-		return nil, fmt.Errorf("report length missmatch: %d (received), %d (expected)", len(report), ReportLen)
+// ParseReport decodes a report built by any supported report codec, the layout version is inferred from the length
+func ParseReport(report types.Report) (Report, error) {
+	version, err := reportVersionFromLength(len(report))
+	if err != nil {
+		return Report{}, err
+	}
+	switch version {
+	case ReportV1:
+		return parseReportV1(report)
+	default:
+		return Report{}, fmt.Errorf("unsupported report version %d", version)
 	}
+}
 
-	// unpack median observation
-	start := int(ReportHeaderLen)
-	end := start + int(MedianLen)
-	median := report[start:end]
-	return bigbigendian.DeserializeSigned(int(MedianLen), median)
+// parseReportV1 decodes a report with the v1 layout, see encodeReportV1
+func parseReportV1(report types.Report) (Report, error) {
+	r := Report{
+		Version:        ReportV1,
+		Timestamp:      binary.BigEndian.Uint32(report[0:4]),
+		ObserversCount: report[4],
+	}
+	if int(r.ObserversCount) > len(r.Observers) {
+		return Report{}, fmt.Errorf("observers count %d exceeds maximum of %d", r.ObserversCount, len(r.Observers))
+	}
+	copy(r.Observers[:], report[5:ReportHeaderLen])
+
+	var err error
+	start := ReportHeaderLen
+	if r.Median, err = bigbigendian.DeserializeSigned(int(MedianLen), report[start:start+MedianLen]); err != nil {
+		return Report{}, fmt.Errorf("error in DeserializeSigned(median): %w", err)
+	}
+	start += MedianLen
+	if r.JuelsPerFeeCoin, err = bigbigendian.DeserializeSigned(int(JuelsLen), report[start:start+JuelsLen]); err != nil {
+		return Report{}, fmt.Errorf("error in DeserializeSigned(juelsPerFeeCoin): %w", err)
+	}
+	return r, nil
+}
+
+// ObserverIDs returns the observers included in the report
+func (r Report) ObserverIDs() []uint8 {
+	n := int(r.ObserversCount)
+	if n > len(r.Observers) {
+		n = len(r.Observers)
+	}
+	return append([]uint8{}, r.Observers[:n]...)
 }
 
 func (c ReportCodec) MaxReportLength(n int) int {
 	return int(ReportLen)
 }
 
-// Create report digest using SHA256 hash fn
+// HashReport returns the report digest signed by oracles, see ReportSigningHash
 func HashReport(ctx types.ReportContext, r types.Report) ([]byte, error) {
-	rawCtx := RawReportContext(ctx)
-	buf := sha256.New()
-	for _, v := range [][]byte{r[:], rawCtx[0][:], rawCtx[1][:], rawCtx[2][:]} {
-		if _, err := buf.Write(v); err != nil {
-			return []byte{}, err
-		}
-	}
-
-	return buf.Sum(nil), nil
+	return ReportSigningHash(ctx, r), nil
 }
 
 func RawReportContext(ctx types.ReportContext) [3][32]byte {
@@ -0,0 +1,116 @@
+package solana
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// ReportVersion identifies a report layout accepted by the OCR2 program
+type ReportVersion uint8
+
+const (
+	// ReportV1 carries the median observation only
+	ReportV1 ReportVersion = 1
+)
+
+var reportLengths = map[ReportVersion]uint64{
+	ReportV1: ReportLen,
+}
+
+// ProgramReportVersions maps the OCR2 program state version to the report layout it accepts
+type ProgramReportVersions map[uint8]ReportVersion
+
+// DefaultProgramReportVersions returns the report layouts of the known program versions.
+// Adding an entry here lets the relay follow a program upgrade as soon as the upgraded state is observed.
+func DefaultProgramReportVersions() ProgramReportVersions {
+	return ProgramReportVersions{
+		1: ReportV1,
+	}
+}
+
+// ForState returns the report layout accepted by the program that owns state.
+// The layout is selected by the program version only, libocr accepts a single offchain config version.
+func (v ProgramReportVersions) ForState(state State) (ReportVersion, error) {
+	version, ok := v[state.Version]
+	if !ok {
+		return 0, fmt.Errorf("no report version known for program state version %d", state.Version)
+	}
+	return version, nil
+}
+
+func reportVersionFromLength(length int) (ReportVersion, error) {
+	for version, l := range reportLengths {
+		if int(l) == length {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("report length missmatch: %d (received), no report version with this length", length)
+}
+
+// NewReportCodec returns the codec building reports with the given layout
+func NewReportCodec(version ReportVersion) (median.ReportCodec, error) {
+	switch version {
+	case ReportV1:
+		return ReportCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report version %d", version)
+	}
+}
+
+var _ median.ReportCodec = (*VersionedReportCodec)(nil)
+
+// VersionedReportCodec builds reports with the layout accepted by the program of the latest observed config,
+// and decodes reports of any supported layout.
+type VersionedReportCodec struct {
+	version func() ReportVersion
+}
+
+// NewVersionedReportCodec returns a codec building reports with the layout returned by version,
+// the layout is selected when the config or program version changes rather than on every report
+func NewVersionedReportCodec(version func() ReportVersion) *VersionedReportCodec {
+	return &VersionedReportCodec{version: version}
+}
+
+func (c *VersionedReportCodec) codec() (median.ReportCodec, error) {
+	return NewReportCodec(c.version())
+}
+
+func (c *VersionedReportCodec) BuildReport(oo []median.ParsedAttributedObservation) (types.Report, error) {
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	return codec.BuildReport(oo)
+}
+
+// MedianFromReport decodes the median of a report of any supported layout,
+// reports built before a program upgrade remain readable
+func (c *VersionedReportCodec) MedianFromReport(report types.Report) (*big.Int, error) {
+	version, err := reportVersionFromLength(len(report))
+	if err != nil {
+		return nil, err
+	}
+	codec, err := NewReportCodec(version)
+	if err != nil {
+		return nil, err
+	}
+	return codec.MedianFromReport(report)
+}
+
+// MaxReportLength returns the report length of the current layout, libocr reads it for every new config
+func (c *VersionedReportCodec) MaxReportLength(n int) int {
+	codec, err := c.codec()
+	if err != nil {
+		max := 0
+		for _, l := range reportLengths {
+			if int(l) > max {
+				max = int(l)
+			}
+		}
+		return max
+	}
+	return codec.MaxReportLength(n)
+}
@@ -0,0 +1,66 @@
+package solana
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testObservationsWithValues(values ...int64) []median.ParsedAttributedObservation {
+	var oo []median.ParsedAttributedObservation
+	for i, v := range values {
+		oo = append(oo, median.ParsedAttributedObservation{
+			Timestamp:       uint32(100 + i),
+			Value:           big.NewInt(v),
+			JuelsPerFeeCoin: big.NewInt(10),
+			Observer:        commontypes.OracleID(i),
+		})
+	}
+	return oo
+}
+
+func TestVersionedReportCodec(t *testing.T) {
+	version := ReportV1
+	c := NewVersionedReportCodec(func() ReportVersion { return version })
+
+	report, err := c.BuildReport(testObservationsWithValues(1, 2, 3))
+	require.NoError(t, err)
+	assert.Len(t, report, int(ReportLen))
+	assert.Equal(t, int(ReportLen), c.MaxReportLength(3))
+	r, err := ParseReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, ReportV1, r.Version)
+
+	med, err := c.MedianFromReport(report)
+	require.NoError(t, err)
+	assert.Equal(t, "2", med.String())
+	_, err = c.MedianFromReport(report[:10])
+	assert.Error(t, err)
+
+	// unsupported layout
+	version = 9
+	_, err = c.BuildReport(testObservationsWithValues(1))
+	assert.Error(t, err)
+	assert.Equal(t, int(ReportLen), c.MaxReportLength(1))
+	_, err = EncodeReport(Report{Version: 9, Median: big.NewInt(1), JuelsPerFeeCoin: big.NewInt(1)})
+	assert.Error(t, err)
+}
+
+func TestProgramReportVersions(t *testing.T) {
+	versions := DefaultProgramReportVersions()
+	version, err := versions.ForState(State{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, ReportV1, version)
+	_, err = versions.ForState(State{Version: 2})
+	assert.Error(t, err)
+
+	// every call returns its own mapping
+	versions[1] = 9
+	version, err = DefaultProgramReportVersions().ForState(State{Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, ReportV1, version)
+}
@@ -0,0 +1,114 @@
+package solana
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+// ReportSignaturesError is returned when the signatures attached to a report would be rejected by the OCR2 program
+type ReportSignaturesError struct {
+	ConfigDigest types.ConfigDigest
+	Signatures   int      // number of attached signatures
+	Required     int      // F+1
+	Signers      []int    // oracle indexes of the distinct valid signers
+	Invalid      []string // reasons individual signatures were rejected
+}
+
+func (e *ReportSignaturesError) Error() string {
+	msg := fmt.Sprintf("invalid report signatures for config %s: %d signatures, %d distinct valid signers, %d required",
+		e.ConfigDigest, e.Signatures, len(e.Signers), e.Required)
+	if len(e.Invalid) > 0 {
+		msg += ": " + strings.Join(e.Invalid, "; ")
+	}
+	return msg
+}
+
+// ReportSigningHash returns the digest signed by the oracles and recovered by the OCR2 program:
+// sha256(len(report) (uint8) || report || report context)
+func ReportSigningHash(ctx types.ReportContext, r types.Report) []byte {
+	rawCtx := RawReportContext(ctx)
+	h := sha256.New()
+	h.Write([]byte{uint8(len(r))})
+	h.Write(r)
+	h.Write(rawCtx[0][:])
+	h.Write(rawCtx[1][:])
+	h.Write(rawCtx[2][:])
+	return h.Sum(nil)
+}
+
+// VerifyReportSignatures recovers the signer of each report signature and maps it to an oracle in state,
+// mirroring the OCR2 program checks: exactly F+1 signatures from distinct oracles of the on-chain oracle set.
+// It returns the oracle indexes of the signers.
+func VerifyReportSignatures(
+	state State,
+	reportCtx types.ReportContext,
+	report types.Report,
+	sigs []types.AttributedOnchainSignature,
+) ([]int, error) {
+	oracles, err := state.Oracles.Data()
+	if err != nil {
+		return nil, err
+	}
+	signers := make([]types.OnchainPublicKey, len(oracles))
+	for i, o := range oracles {
+		o := o
+		signers[i] = o.Signer.Key[:]
+	}
+	return verifyReportSignatures(signers, state.Config.F, reportCtx, report, sigs)
+}
+
+// verifyReportSignatures checks the report signatures against the signers and f of a config
+func verifyReportSignatures(
+	signers []types.OnchainPublicKey,
+	f uint8,
+	reportCtx types.ReportContext,
+	report types.Report,
+	sigs []types.AttributedOnchainSignature,
+) ([]int, error) {
+	hash := ReportSigningHash(reportCtx, report)
+	verifyErr := &ReportSignaturesError{
+		ConfigDigest: reportCtx.ConfigDigest,
+		Signatures:   len(sigs),
+		Required:     int(f) + 1,
+	}
+	seen := map[int]bool{}
+	for i, sig := range sigs {
+		if len(sig.Signature) != SignatureLen {
+			verifyErr.Invalid = append(verifyErr.Invalid, fmt.Sprintf("signature %d: invalid length %d", i, len(sig.Signature)))
+			continue
+		}
+		pubkey, err := crypto.SigToPub(hash, sig.Signature)
+		if err != nil {
+			verifyErr.Invalid = append(verifyErr.Invalid, fmt.Sprintf("signature %d: %s", i, err))
+			continue
+		}
+		address := crypto.PubkeyToAddress(*pubkey)
+
+		index := -1
+		for j, signer := range signers {
+			if bytes.Equal(signer, address[:]) {
+				index = j
+				break
+			}
+		}
+		switch {
+		case index < 0:
+			verifyErr.Invalid = append(verifyErr.Invalid, fmt.Sprintf("signature %d: signer %s is not in the oracle set", i, address))
+		case seen[index]:
+			verifyErr.Invalid = append(verifyErr.Invalid, fmt.Sprintf("signature %d: duplicate signer oracle %d", i, index))
+		default:
+			seen[index] = true
+			verifyErr.Signers = append(verifyErr.Signers, index)
+		}
+	}
+
+	if len(verifyErr.Invalid) > 0 || len(sigs) != verifyErr.Required || len(verifyErr.Signers) != verifyErr.Required {
+		return verifyErr.Signers, verifyErr
+	}
+	return verifyErr.Signers, nil
+}
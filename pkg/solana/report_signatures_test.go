@@ -0,0 +1,98 @@
+package solana
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+)
+
+// testSigningKeys adds n oracles with report signing keys to state
+func testSigningKeys(t *testing.T, state *State, n int) []*ecdsa.PrivateKey {
+	keys := make([]*ecdsa.PrivateKey, n)
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		keys[i] = key
+		state.Oracles.Raw[i].Signer.Key = crypto.PubkeyToAddress(key.PublicKey)
+	}
+	state.Oracles.Len = uint64(n)
+	return keys
+}
+
+func testSign(t *testing.T, key *ecdsa.PrivateKey, reportCtx types.ReportContext, report types.Report) types.AttributedOnchainSignature {
+	sig, err := crypto.Sign(ReportSigningHash(reportCtx, report), key)
+	require.NoError(t, err)
+	return types.AttributedOnchainSignature{Signature: sig}
+}
+
+func TestVerifyReportSignatures(t *testing.T) {
+	var state State
+	state.Config.F = 1
+	keys := testSigningKeys(t, &state, 4)
+	outsider, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest, Epoch: 1, Round: 2}}
+	report := make(types.Report, ReportLen)
+	report[0] = 1
+
+	signers, err := VerifyReportSignatures(state, reportCtx, report, []types.AttributedOnchainSignature{
+		testSign(t, keys[3], reportCtx, report),
+		testSign(t, keys[1], reportCtx, report),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, signers)
+
+	otherReport := append(types.Report{}, report...)
+	otherReport[0] = 2
+	for name, sigs := range map[string][]types.AttributedOnchainSignature{
+		"too few":      {testSign(t, keys[0], reportCtx, report)},
+		"too many":     {testSign(t, keys[0], reportCtx, report), testSign(t, keys[1], reportCtx, report), testSign(t, keys[2], reportCtx, report)},
+		"duplicate":    {testSign(t, keys[0], reportCtx, report), testSign(t, keys[0], reportCtx, report)},
+		"unknown":      {testSign(t, keys[0], reportCtx, report), testSign(t, outsider, reportCtx, report)},
+		"other report": {testSign(t, keys[0], reportCtx, report), testSign(t, keys[1], reportCtx, otherReport)},
+		"malformed":    {testSign(t, keys[0], reportCtx, report), {Signature: make([]byte, SignatureLen-1)}},
+	} {
+		_, err := VerifyReportSignatures(state, reportCtx, report, sigs)
+		var sigErr *ReportSignaturesError
+		require.True(t, errors.As(err, &sigErr), name)
+		assert.Equal(t, 2, sigErr.Required, name)
+		assert.Equal(t, len(sigs), sigErr.Signatures, name)
+	}
+}
+
+func TestTransmit_VerifyReportSignatures(t *testing.T) {
+	reader := new(mocks.ReaderWriter)
+	tracker, txm := testSetupTransmitter(t, reader, 0)
+	tracker.cfg.Update(db.ChainCfg{VerifyReportSignatures: null.BoolFrom(true)})
+	reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(6000), nil).Once()
+
+	tracker.state.Config.F = 1
+	keys := testSigningKeys(t, &tracker.state, 4)
+	tracker.state.Oracles.Raw[0].Transmitter = tracker.Transmitter.PublicKey()
+
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest}}
+	report := make(types.Report, ReportLen)
+
+	// invalid signature set is refused before building the transaction
+	err := tracker.Transmit(context.Background(), reportCtx, report, []types.AttributedOnchainSignature{testSign(t, keys[0], reportCtx, report)})
+	var sigErr *ReportSignaturesError
+	require.True(t, errors.As(err, &sigErr))
+	assert.Empty(t, txm.txs)
+
+	require.NoError(t, tracker.Transmit(context.Background(), reportCtx, report, []types.AttributedOnchainSignature{
+		testSign(t, keys[0], reportCtx, report),
+		testSign(t, keys[2], reportCtx, report),
+	}))
+	assert.Len(t, txm.txs, 1)
+}
@@ -183,11 +183,12 @@ func TestHashReport(t *testing.T) {
 		13, 224, 182, 179, 167, 100, 0, 0, // juels per sol (1 with 18 decimal places)
 	}
 
+	// sha256(len(report) as u8 || report || report context)
 	var mockHash = []byte{
-		0x9a, 0xe0, 0xc3, 0x7d, 0x9d, 0x45, 0x58, 0xdc,
-		0x1e, 0x8b, 0xbc, 0xf4, 0x7d, 0x6b, 0xc8, 0xb0,
-		0x5, 0xbe, 0xbe, 0x5f, 0xd, 0x28, 0x33, 0x3b,
-		0x27, 0x11, 0x33, 0x5f, 0xed, 0x43, 0x91, 0x60,
+		0xe6, 0x39, 0xcb, 0xaa, 0xb5, 0xea, 0xbd, 0x9f,
+		0x43, 0x29, 0xc6, 0x8b, 0x72, 0x51, 0x40, 0xe7,
+		0xc6, 0x6d, 0x51, 0xd2, 0x99, 0x1c, 0xe, 0x6a,
+		0xda, 0x45, 0x19, 0x38, 0x1d, 0xd6, 0x1, 0xb2,
 	}
 
 	h, err := HashReport(mockReportCtx, mockReport)
@@ -254,3 +255,61 @@ func TestReportHandleOverflow(t *testing.T) {
 	_, err = c.BuildReport(oo)
 	assert.Error(t, err)
 }
+
+func TestParseReport(t *testing.T) {
+	c := ReportCodec{}
+	var oo []median.ParsedAttributedObservation
+	for i := 0; i < 5; i++ {
+		oo = append(oo, median.ParsedAttributedObservation{
+			Timestamp:       uint32(100 + i),
+			Value:           big.NewInt(int64(i) - 2),
+			JuelsPerFeeCoin: big.NewInt(int64(1000 + i)),
+			Observer:        commontypes.OracleID(4 - i),
+		})
+	}
+	raw, err := c.BuildReport(oo)
+	require.NoError(t, err)
+
+	r, err := ParseReport(raw)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(102), r.Timestamp)
+	assert.Equal(t, uint8(5), r.ObserversCount)
+	assert.Equal(t, []uint8{4, 3, 2, 1, 0}, r.ObserverIDs())
+	assert.Equal(t, "0", r.Median.String())
+	assert.Equal(t, "1002", r.JuelsPerFeeCoin.String())
+
+	med, err := c.MedianFromReport(raw)
+	require.NoError(t, err)
+	assert.Equal(t, med, r.Median)
+
+	// encoding is symmetric
+	encoded, err := EncodeReport(r)
+	require.NoError(t, err)
+	assert.Equal(t, raw, encoded)
+
+	// on-chain report fixture
+	r, err = ParseReport(types.Report{
+		97, 91, 43, 83, // observations_timestamp
+		2,                                                                                              // observer_count
+		0, 1, 2, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, // observers
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 73, 150, 2, 210, // observation 2
+		13, 224, 182, 179, 167, 100, 0, 0, // juels per luna (1 with 18 decimal places)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x615b2b53), r.Timestamp)
+	assert.Equal(t, []uint8{0, 1}, r.ObserverIDs())
+	assert.Equal(t, "1234567890", r.Median.String())
+	assert.Equal(t, "1000000000000000000", r.JuelsPerFeeCoin.String())
+
+	// invalid reports
+	_, err = ParseReport(raw[:len(raw)-1])
+	assert.Error(t, err)
+	invalid := append(types.Report{}, raw...)
+	invalid[4] = 33
+	_, err = ParseReport(invalid)
+	assert.Error(t, err)
+	_, err = EncodeReport(Report{ObserversCount: 33, Median: big.NewInt(1), JuelsPerFeeCoin: big.NewInt(1)})
+	assert.Error(t, err)
+	_, err = EncodeReport(Report{ObserversCount: 1})
+	assert.Error(t, err)
+}
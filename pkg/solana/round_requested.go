@@ -0,0 +1,140 @@
+package solana
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+// roundRequestedPageSize is the number of transactions fetched per page when scanning back for the latest request
+const roundRequestedPageSize = 25
+
+// roundRequests tracks the latest RoundRequested event of a feed.
+// request_new_round is the only instruction of a feed taking the requester access controller, so the transactions of
+// that account are scanned rather than the state account, where nearly every transaction is a transmission.
+// The access controller may be shared by feeds, requests are matched to the feed by config digest.
+type roundRequests struct {
+	lock       sync.Mutex
+	client     events.Client
+	programID  solana.PublicKey
+	commitment rpc.CommitmentType
+	lggr       logger.Logger
+
+	accessController solana.PublicKey
+	fetcher          *events.Fetcher  // transactions of the access controller
+	cursor           solana.Signature // latest transaction scanned, zero until a scan finds a transaction
+	latest           *events.RoundRequested
+	latestTime       time.Time
+	scanTime         time.Time
+}
+
+func newRoundRequests(client events.Client, programID solana.PublicKey, commitment rpc.CommitmentType, lggr logger.Logger) *roundRequests {
+	return &roundRequests{client: client, programID: programID, commitment: commitment, lggr: lggr}
+}
+
+// Latest returns the latest RoundRequested event of the feed in state emitted within lookback and not followed by
+// a transmission, or nil if there is none. A transmission advances the state epoch and round past those of the request.
+// History is scanned at most once per refresh period, and only past the previous scan.
+func (r *roundRequests) Latest(ctx context.Context, state State, lookback, refresh time.Duration) (*events.RoundRequested, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	accessController := state.Config.RequesterAccessController
+	if accessController.IsZero() {
+		return nil, nil
+	}
+	if r.fetcher == nil || !accessController.Equals(r.accessController) {
+		r.accessController = accessController
+		r.fetcher = events.NewFetcher(r.client, r.programID, accessController, r.commitment, r.lggr)
+		r.cursor, r.latest, r.scanTime = solana.Signature{}, nil, time.Time{}
+	}
+
+	now := time.Now()
+	start := now.Add(-lookback)
+	digest := state.Config.LatestConfigDigest
+	if now.Sub(r.scanTime) >= refresh {
+		var err error
+		if r.cursor.IsZero() {
+			err = r.scanBack(ctx, digest, start)
+		} else {
+			err = r.scanForward(ctx, digest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.scanTime = now
+	}
+
+	if r.latest == nil || r.latestTime.Before(start) {
+		return nil, nil
+	}
+	if r.latest.ConfigDigest != digest || r.latest.Epoch != state.Config.Epoch || r.latest.Round != state.Config.Round {
+		return nil, nil
+	}
+	return r.latest, nil
+}
+
+// scanBack pages back from the latest transaction until a request for digest is found, or start is reached
+func (r *roundRequests) scanBack(ctx context.Context, digest [32]byte, start time.Time) error {
+	var cursor, before solana.Signature
+	var latest *events.RoundRequested
+	var latestTime time.Time
+scan:
+	for {
+		txs, next, err := r.fetcher.Page(ctx, before, roundRequestedPageSize)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			if cursor.IsZero() {
+				cursor = tx.Signature
+			}
+			if tx.BlockTime != nil && tx.BlockTime.Time().Before(start) {
+				break scan
+			}
+			for i := len(tx.Events) - 1; i >= 0; i-- {
+				if e, ok := tx.Events[i].(*events.RoundRequested); ok && e.ConfigDigest == digest {
+					latest, latestTime = e, blockTime(tx)
+					break scan
+				}
+			}
+		}
+		if next.IsZero() {
+			break
+		}
+		before = next
+	}
+	r.cursor, r.latest, r.latestTime = cursor, latest, latestTime
+	return nil
+}
+
+// scanForward applies the requests for digest in the transactions after the cursor
+func (r *roundRequests) scanForward(ctx context.Context, digest [32]byte) error {
+	txs, cursor, err := r.fetcher.Since(ctx, r.cursor)
+	if err != nil {
+		return err
+	}
+	for _, tx := range txs {
+		for _, event := range tx.Events {
+			if e, ok := event.(*events.RoundRequested); ok && e.ConfigDigest == digest {
+				r.latest, r.latestTime = e, blockTime(tx)
+			}
+		}
+	}
+	r.cursor = cursor
+	return nil
+}
+
+// blockTime returns the block time of a transaction, block times are not always available for recent blocks
+func blockTime(tx events.TxEvents) time.Time {
+	if tx.BlockTime == nil {
+		return time.Now()
+	}
+	return tx.BlockTime.Time()
+}
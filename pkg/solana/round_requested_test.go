@@ -0,0 +1,200 @@
+package solana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+)
+
+type testHistoryTx struct {
+	sig       solana.Signature
+	blockTime solana.UnixTimeSeconds
+	logs      []string
+}
+
+// testHistory serves the transactions of an account, oldest first
+type testHistory struct {
+	programID solana.PublicKey
+	txs       []testHistoryTx
+	calls     int
+	account   solana.PublicKey // account of the latest signatures query
+	err       error
+}
+
+func (h *testHistory) add(t *testing.T, blockTime time.Time, evts ...events.Event) {
+	logs := []string{"Program " + h.programID.String() + " invoke [1]"}
+	for _, e := range evts {
+		d := events.Discriminator(e.EventName())
+		buf := bytes.NewBuffer(d[:])
+		require.NoError(t, bin.NewBorshEncoder(buf).Encode(e))
+		logs = append(logs, "Program data: "+base64.StdEncoding.EncodeToString(buf.Bytes()))
+	}
+	logs = append(logs, "Program "+h.programID.String()+" success")
+	h.txs = append(h.txs, testHistoryTx{
+		sig:       solana.Signature{byte(len(h.txs) + 1)},
+		blockTime: solana.UnixTimeSeconds(blockTime.Unix()),
+		logs:      logs,
+	})
+}
+
+func (h *testHistory) GetSignaturesForAddressWithOpts(_ context.Context, account solana.PublicKey, opts *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	h.calls++
+	h.account = account
+	if h.err != nil {
+		return nil, h.err
+	}
+	var out []*rpc.TransactionSignature
+	started := opts.Before.IsZero()
+	for i := len(h.txs) - 1; i >= 0 && len(out) < *opts.Limit; i-- {
+		tx := h.txs[i]
+		if !started {
+			started = tx.sig == opts.Before
+			continue
+		}
+		if tx.sig == opts.Until {
+			break
+		}
+		blockTime := tx.blockTime
+		out = append(out, &rpc.TransactionSignature{Signature: tx.sig, Slot: uint64(i), BlockTime: &blockTime})
+	}
+	return out, nil
+}
+
+func (h *testHistory) GetTransaction(_ context.Context, txSig solana.Signature, _ *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	for i, tx := range h.txs {
+		if tx.sig == txSig {
+			return &rpc.GetTransactionResult{Slot: uint64(i), Meta: &rpc.TransactionMeta{LogMessages: tx.logs}}, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func TestRoundRequests_Latest(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	h := &testHistory{programID: solana.NewWallet().PublicKey()}
+	r := newRoundRequests(h, h.programID, rpc.CommitmentConfirmed, logger.TestLogger(t))
+	var state State
+	state.Config.LatestConfigDigest = [32]byte{1}
+	state.Config.Epoch, state.Config.Round = 2, 3
+
+	// requests are not possible without an access controller
+	rr, err := r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+	assert.Zero(t, h.calls)
+
+	// no history
+	state.Config.RequesterAccessController = solana.NewWallet().PublicKey()
+	rr, err = r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+	assert.True(t, r.cursor.IsZero())
+	assert.Equal(t, state.Config.RequesterAccessController, h.account)
+
+	// scan back stops at the latest request of the feed
+	h.add(t, now.Add(-3*time.Minute), &events.RoundRequested{ConfigDigest: [32]byte{1}, Epoch: 1, Round: 1})
+	h.add(t, now.Add(-time.Minute), &events.RoundRequested{ConfigDigest: [32]byte{1}, Epoch: 2, Round: 3})
+	h.add(t, now.Add(-time.Minute), &events.RoundRequested{ConfigDigest: [32]byte{2}, Epoch: 2, Round: 3})
+	h.add(t, now.Add(-time.Minute))
+	rr, err = r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	require.NotNil(t, rr)
+	assert.Equal(t, events.RoundRequested{ConfigDigest: [32]byte{1}, Epoch: 2, Round: 3}, *rr)
+
+	// outside the lookback window
+	rr, err = r.Latest(ctx, state, 30*time.Second, 0)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+
+	// cached within the refresh period
+	calls := h.calls
+	h.add(t, now, &events.RoundRequested{ConfigDigest: [32]byte{1}, Epoch: 2, Round: 4})
+	rr, err = r.Latest(ctx, state, time.Hour, time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, rr)
+	assert.Equal(t, uint8(3), rr.Round)
+	assert.Equal(t, calls, h.calls)
+
+	// a transmission after the request advances the state past it
+	state.Config.Round = 4
+	rr, err = r.Latest(ctx, state, time.Hour, time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+
+	// new requests are picked up incrementally
+	rr, err = r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	require.NotNil(t, rr)
+	assert.Equal(t, uint8(4), rr.Round)
+	assert.Equal(t, calls+1, h.calls)
+
+	// requests of other feeds sharing the access controller are ignored
+	h.add(t, now, &events.RoundRequested{ConfigDigest: [32]byte{2}, Epoch: 2, Round: 4})
+	rr, err = r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	require.NotNil(t, rr)
+	assert.Equal(t, [32]byte{1}, rr.ConfigDigest)
+
+	// a new access controller is scanned from scratch
+	state.Config.RequesterAccessController = solana.NewWallet().PublicKey()
+	h.txs = nil
+	rr, err = r.Latest(ctx, state, time.Hour, 0)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+	assert.Equal(t, state.Config.RequesterAccessController, h.account)
+
+	// rpc errors are returned
+	h.err = errors.New("rpc unavailable")
+	_, err = r.Latest(ctx, state, time.Hour, 0)
+	assert.Error(t, err)
+}
+
+func TestRoundRequests_ScanBackLookback(t *testing.T) {
+	now := time.Now()
+	h := &testHistory{programID: solana.NewWallet().PublicKey()}
+	h.add(t, now.Add(-time.Hour), &events.RoundRequested{Epoch: 1, Round: 1})
+	for i := 0; i < 2*roundRequestedPageSize; i++ {
+		h.add(t, now.Add(-time.Minute))
+	}
+	r := newRoundRequests(h, h.programID, rpc.CommitmentConfirmed, logger.TestLogger(t))
+	var state State
+	state.Config.RequesterAccessController = solana.NewWallet().PublicKey()
+	state.Config.Epoch, state.Config.Round = 1, 1
+
+	rr, err := r.Latest(context.Background(), state, 10*time.Minute, 0)
+	require.NoError(t, err)
+	assert.Nil(t, rr)
+	assert.Equal(t, h.txs[len(h.txs)-1].sig, r.cursor)
+}
+
+func TestLatestRoundRequested(t *testing.T) {
+	reader := new(mocks.ReaderWriter)
+	tracker, _ := testSetupTransmitter(t, reader, 0)
+	tracker.state.Config.RequesterAccessController = solana.NewWallet().PublicKey()
+	tracker.state.Config.Epoch, tracker.state.Config.Round = 4, 2
+	h := &testHistory{programID: tracker.ProgramID}
+	h.add(t, time.Now(), &events.RoundRequested{ConfigDigest: testConfigDigest, Epoch: 4, Round: 2})
+	tracker.roundRequests = newRoundRequests(h, tracker.ProgramID, rpc.CommitmentConfirmed, logger.TestLogger(t))
+
+	digest, epoch, round, err := tracker.LatestRoundRequested(context.Background(), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, testConfigDigest, digest)
+	assert.Equal(t, uint32(4), epoch)
+	assert.Equal(t, uint8(2), round)
+	assert.Equal(t, tracker.state.Config.RequesterAccessController, h.account)
+}
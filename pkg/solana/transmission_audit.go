@@ -0,0 +1,61 @@
+package solana
+
+import (
+	"context"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/audit"
+)
+
+// audit appends a record of the Transmit call to the audit log, failures are logged but never fail the transmission
+func (c *ContractTracker) audit(
+	ctx context.Context,
+	reportCtx types.ReportContext,
+	report types.Report,
+	sigs []types.AttributedOnchainSignature,
+	txSig solana.Signature,
+	transmitErr error,
+) {
+	record := audit.Record{
+		Time:         time.Now(),
+		StateID:      c.StateID,
+		ConfigDigest: reportCtx.ConfigDigest,
+		Epoch:        reportCtx.Epoch,
+		Round:        reportCtx.Round,
+		Signatures:   len(sigs),
+		TxSignature:  txSig,
+		Status:       audit.StatusQueued,
+	}
+	if transmitErr != nil {
+		record.Status = audit.StatusFailed
+		record.Error = transmitErr.Error()
+	}
+
+	if r, err := ParseReport(report); err == nil {
+		record.Median = r.Median
+		record.ReportTimestamp = r.Timestamp
+		for _, o := range r.ObserverIDs() {
+			record.Observers = append(record.Observers, int(o))
+		}
+	} else {
+		c.lggr.Warnf("failed to decode report for transmission audit: %s", err)
+	}
+
+	if err := c.auditLog.Append(ctx, record); err != nil {
+		c.lggr.Errorf("failed to append transmission audit record for state %s: %s", c.StateID, err)
+	}
+}
+
+// resolveAuditStatus records the final on-chain status of audited transmissions when the reader supports signature statuses
+func (c *ContractTracker) resolveAuditStatus(ctx context.Context) {
+	checker, ok := c.reader.(audit.StatusChecker)
+	if !ok {
+		return
+	}
+	if err := c.auditLog.ResolvePending(ctx, checker, c.cfg.Commitment(), c.cfg.TxTimeout()); err != nil {
+		c.lggr.Errorf("failed to resolve transmission audit status for state %s: %s", c.StateID, err)
+	}
+}
@@ -0,0 +1,243 @@
+package solana
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/events"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/logger"
+)
+
+// ReportContextLen = config digest + (27 byte padding, 4 byte epoch, 1 byte round) + extra hash
+const ReportContextLen = 3 * 32
+
+// TransactionReader fetches confirmed transactions and the lookup tables they load accounts from,
+// it is satisfied by the relay client
+type TransactionReader interface {
+	client.AccountReader
+	GetTransaction(ctx context.Context, txSig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error)
+}
+
+// TransmitTx is a decoded OCR2 transmit transaction
+type TransmitTx struct {
+	Signature   solana.Signature
+	Slot        uint64
+	BlockTime   *solana.UnixTimeSeconds
+	Err         interface{} // transaction error, nil if the transmission succeeded
+	ProgramID   solana.PublicKey
+	StateID     solana.PublicKey
+	Transmitter solana.PublicKey
+
+	StoreNonce    uint8
+	ReportContext types.ReportContext
+	RawReport     types.Report
+	Report        Report
+	Signatures    []types.AttributedOnchainSignature
+}
+
+// ConfigNotInEffectError is returned when verifying a transmission whose report was not signed for the config in effect
+// at the transmission, or when that config cannot be recovered
+type ConfigNotInEffectError struct {
+	Report   types.ConfigDigest
+	InEffect types.ConfigDigest // zero if the config in effect is not available
+	Slot     uint64             // transmission slot
+	Err      error              // reason the config in effect is not available
+}
+
+func (e *ConfigNotInEffectError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("config in effect at slot %d is not available: %s", e.Slot, e.Err)
+	}
+	return fmt.Sprintf("report config digest %s does not match config digest %s in effect at slot %d",
+		e.Report, e.InEffect, e.Slot)
+}
+
+func (e *ConfigNotInEffectError) Unwrap() error {
+	return e.Err
+}
+
+// ParseTransmitInstruction splits the transmit instruction payload:
+// store_nonce || report_context || raw_report || raw_signatures
+// The report length is inferred from the supported report layouts.
+func ParseTransmitInstruction(data []byte) (storeNonce uint8, reportCtx types.ReportContext, report types.Report, sigs []types.AttributedOnchainSignature, err error) {
+	headerLen := -1
+	for _, l := range reportLengths {
+		n := 1 + ReportContextLen + int(l)
+		if len(data) > n && (len(data)-n)%SignatureLen == 0 {
+			headerLen = n
+			break
+		}
+	}
+	if headerLen < 0 {
+		return 0, reportCtx, nil, nil, fmt.Errorf("invalid transmit instruction data length: %d", len(data))
+	}
+	storeNonce = data[0]
+
+	raw := data[1 : 1+ReportContextLen]
+	copy(reportCtx.ConfigDigest[:], raw[0:32])
+	reportCtx.Epoch = binary.BigEndian.Uint32(raw[32+27 : 32+31])
+	reportCtx.Round = raw[32+31]
+	copy(reportCtx.ExtraHash[:], raw[64:96])
+
+	report = append(types.Report{}, data[1+ReportContextLen:headerLen]...)
+	for offset := headerLen; offset < len(data); offset += SignatureLen {
+		sigs = append(sigs, types.AttributedOnchainSignature{
+			Signature: append([]byte{}, data[offset:offset+SignatureLen]...),
+		})
+	}
+	return storeNonce, reportCtx, report, sigs, nil
+}
+
+// DecodeTransmitTx finds and decodes the transmit instruction for programID in a legacy transaction
+func DecodeTransmitTx(tx *solana.Transaction, programID solana.PublicKey) (TransmitTx, error) {
+	out, err := decodeTransmitInstruction(tx.Message.AccountKeys, tx.Message.Instructions, programID)
+	if err != nil {
+		return TransmitTx{}, err
+	}
+	if len(tx.Signatures) > 0 {
+		out.Signature = tx.Signatures[0]
+	}
+	return out, nil
+}
+
+// decodeTransmitInstruction decodes the first transmit instruction for programID, keys are the message account keys
+func decodeTransmitInstruction(keys []solana.PublicKey, instructions []solana.CompiledInstruction, programID solana.PublicKey) (TransmitTx, error) {
+	for _, ix := range instructions {
+		if int(ix.ProgramIDIndex) >= len(keys) || !keys[ix.ProgramIDIndex].Equals(programID) {
+			continue
+		}
+		// accounts: state, transmitter, transmissions, store_program, store, store_authority
+		if len(ix.Accounts) < 2 {
+			continue
+		}
+		nonce, reportCtx, raw, sigs, err := ParseTransmitInstruction(ix.Data)
+		if err != nil {
+			continue // not a transmit instruction
+		}
+		report, err := ParseReport(raw)
+		if err != nil {
+			return TransmitTx{}, errors.Wrap(err, "error in DecodeTransmitTx.ParseReport")
+		}
+		out := TransmitTx{
+			ProgramID:     programID,
+			StoreNonce:    nonce,
+			ReportContext: reportCtx,
+			RawReport:     raw,
+			Report:        report,
+			Signatures:    sigs,
+		}
+		for i, key := range []*solana.PublicKey{&out.StateID, &out.Transmitter} {
+			if int(ix.Accounts[i]) >= len(keys) {
+				return TransmitTx{}, fmt.Errorf("transmit instruction account index %d out of range", ix.Accounts[i])
+			}
+			*key = keys[ix.Accounts[i]]
+		}
+		return out, nil
+	}
+	return TransmitTx{}, fmt.Errorf("no transmit instruction for program %s found in transaction", programID)
+}
+
+// FetchTransmitTx fetches a legacy or v0 transaction by signature and decodes its transmit instruction
+func FetchTransmitTx(ctx context.Context, reader TransactionReader, programID solana.PublicKey, txSig solana.Signature, commitment rpc.CommitmentType) (TransmitTx, error) {
+	res, keys, instructions, err := fetchTransaction(ctx, reader, txSig, commitment)
+	if err != nil {
+		return TransmitTx{}, err
+	}
+	out, err := decodeTransmitInstruction(keys, instructions, programID)
+	if err != nil {
+		return TransmitTx{}, err
+	}
+	out.Signature = txSig
+	out.Slot = res.Slot
+	out.BlockTime = res.BlockTime
+	if res.Meta != nil {
+		out.Err = res.Meta.Err
+	}
+	return out, nil
+}
+
+// fetchTransaction fetches a legacy or v0 transaction and returns its account keys and instructions,
+// the accounts loaded from address lookup tables are resolved with reader.
+func fetchTransaction(ctx context.Context, reader TransactionReader, txSig solana.Signature, commitment rpc.CommitmentType) (*rpc.GetTransactionResult, []solana.PublicKey, []solana.CompiledInstruction, error) {
+	version := uint64(0)
+	res, err := reader.GetTransaction(ctx, txSig, &client.GetTransactionOpts{
+		Encoding:                       solana.EncodingBase64,
+		Commitment:                     commitment,
+		MaxSupportedTransactionVersion: &version,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch transaction %s: %w", txSig, err)
+	}
+	if res == nil || res.Transaction == nil {
+		return nil, nil, nil, errors.New("nil pointer returned in fetchTransaction.GetTransaction")
+	}
+
+	raw := res.Transaction.GetBinary()
+	if !IsVersionedTransaction(raw) {
+		var tx solana.Transaction
+		if err = bin.NewBinDecoder(raw).Decode(&tx); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode transaction %s: %w", txSig, err)
+		}
+		return res, tx.Message.AccountKeys, tx.Message.Instructions, nil
+	}
+	var tx VersionedTransaction
+	if err = tx.UnmarshalBinary(raw); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to decode transaction %s: %w", txSig, err)
+	}
+	keys, err := tx.Message.ResolveAccountKeys(ctx, reader, commitment)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error in fetchTransaction.ResolveAccountKeys")
+	}
+	return res, keys, tx.Message.Instructions, nil
+}
+
+// VerifySignatures checks the transmission signatures against the oracle set of the config in effect at the transmission.
+// This is the config of state if the report was signed for it, otherwise the config set by the latest SetConfig event
+// of the state account before the transmission, rebuilt from its proposal.
+func (t TransmitTx) VerifySignatures(ctx context.Context, reader HistoryReader, state State, commitment rpc.CommitmentType, lggr logger.Logger) ([]int, error) {
+	cfg, err := t.configInEffect(ctx, reader, state, commitment, lggr)
+	if err != nil {
+		return nil, &ConfigNotInEffectError{Report: t.ReportContext.ConfigDigest, Slot: t.Slot, Err: err}
+	}
+	if cfg.ConfigDigest != t.ReportContext.ConfigDigest {
+		return nil, &ConfigNotInEffectError{Report: t.ReportContext.ConfigDigest, InEffect: cfg.ConfigDigest, Slot: t.Slot}
+	}
+	return verifyReportSignatures(cfg.Signers, cfg.F, t.ReportContext, t.RawReport, t.Signatures)
+}
+
+// configInEffect returns the config in effect at the transmission, scanning the state account history back from it
+func (t TransmitTx) configInEffect(ctx context.Context, reader HistoryReader, state State, commitment rpc.CommitmentType, lggr logger.Logger) (types.ContractConfig, error) {
+	if t.ReportContext.ConfigDigest == state.Config.LatestConfigDigest {
+		return ConfigFromState(state)
+	}
+	if t.Signature.IsZero() {
+		return types.ContractConfig{}, errors.New("transmission signature is required to look up its config")
+	}
+
+	fetcher := events.NewFetcher(reader, t.ProgramID, t.StateID, commitment, lggr)
+	for before := t.Signature; ; {
+		txs, cursor, err := fetcher.Page(ctx, before, events.MaxPageSize)
+		if err != nil {
+			return types.ContractConfig{}, errors.Wrap(err, "error in configInEffect.Page")
+		}
+		for _, tx := range txs {
+			for i := len(tx.Events) - 1; i >= 0; i-- {
+				if sc, ok := tx.Events[i].(*events.SetConfig); ok {
+					return ConfigFromProposal(ctx, reader, t.ProgramID, t.StateID, state, tx.Signature, sc.ConfigDigest, commitment)
+				}
+			}
+		}
+		if cursor.IsZero() {
+			return types.ContractConfig{}, fmt.Errorf("no SetConfig event found before transaction %s", t.Signature)
+		}
+		before = cursor
+	}
+}
@@ -0,0 +1,253 @@
+package solana
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client"
+)
+
+type testTxReader struct {
+	results  map[solana.Signature]*rpc.GetTransactionResult
+	accounts map[solana.PublicKey]*rpc.GetAccountInfoResult
+}
+
+func (r testTxReader) GetTransaction(_ context.Context, sig solana.Signature, opts *client.GetTransactionOpts) (*rpc.GetTransactionResult, error) {
+	res, ok := r.results[sig]
+	if !ok {
+		return nil, rpc.ErrNotFound
+	}
+	if IsVersionedTransaction(res.Transaction.GetBinary()) && (opts == nil || opts.MaxSupportedTransactionVersion == nil) {
+		return nil, errors.New("Transaction version (0) is not supported by the requesting client")
+	}
+	return res, nil
+}
+
+func (r testTxReader) GetSignaturesForAddressWithOpts(context.Context, solana.PublicKey, *rpc.GetSignaturesForAddressOpts) ([]*rpc.TransactionSignature, error) {
+	return nil, nil
+}
+
+func (r testTxReader) GetAccountInfoWithOpts(_ context.Context, addr solana.PublicKey, _ *rpc.GetAccountInfoOpts) (*rpc.GetAccountInfoResult, error) {
+	res, ok := r.accounts[addr]
+	if !ok {
+		return nil, rpc.ErrNotFound
+	}
+	return res, nil
+}
+
+// testTxResult returns the base64 encoded getTransaction response of a serialized transaction
+func testTxResult(t *testing.T, slot uint64, raw []byte) *rpc.GetTransactionResult {
+	var envelope rpc.TransactionResultEnvelope
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`["%s","base64"]`, base64.StdEncoding.EncodeToString(raw))), &envelope))
+	return &rpc.GetTransactionResult{Slot: slot, Transaction: &envelope, Meta: &rpc.TransactionMeta{}}
+}
+
+func TestFetchTransmitTx(t *testing.T) {
+	var state State
+	state.Config.F = 1
+	state.Config.LatestConfigDigest = testConfigDigest
+	keys := testSigningKeys(t, &state, 4)
+
+	programID, stateID, transmissionsID, storeProgramID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	transmitter, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	storeAuthority, nonce, err := FindStoreAuthority(programID, stateID)
+	require.NoError(t, err)
+
+	reportCtx := types.ReportContext{
+		ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest, Epoch: 7, Round: 3},
+		ExtraHash:       [32]byte{9},
+	}
+	report, err := EncodeReport(Report{Timestamp: 1000, ObserversCount: 2, Observers: [32]uint8{2, 0}, Median: big.NewInt(1234), JuelsPerFeeCoin: big.NewInt(5678)})
+	require.NoError(t, err)
+	sigs := []types.AttributedOnchainSignature{testSign(t, keys[2], reportCtx, report), testSign(t, keys[0], reportCtx, report)}
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{newTransmitInstruction(programID, stateID, transmitter.PublicKey(), transmissionsID, storeProgramID, storeAuthority, nonce, reportCtx, report, sigs)},
+		solana.Hash{1},
+		solana.TransactionPayer(transmitter.PublicKey()),
+	)
+	require.NoError(t, err)
+	_, err = tx.Sign(func(solana.PublicKey) *solana.PrivateKey { return &transmitter })
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	reader := testTxReader{results: map[solana.Signature]*rpc.GetTransactionResult{
+		tx.Signatures[0]: testTxResult(t, 42, raw),
+	}}
+
+	decoded, err := FetchTransmitTx(context.Background(), reader, programID, tx.Signatures[0], rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signatures[0], decoded.Signature)
+	assert.Equal(t, uint64(42), decoded.Slot)
+	assert.Nil(t, decoded.Err)
+	assert.Equal(t, stateID, decoded.StateID)
+	assert.Equal(t, transmitter.PublicKey(), decoded.Transmitter)
+	assert.Equal(t, nonce, decoded.StoreNonce)
+	assert.Equal(t, reportCtx, decoded.ReportContext)
+	assert.Equal(t, report, decoded.RawReport)
+	assert.Equal(t, uint32(1000), decoded.Report.Timestamp)
+	assert.Equal(t, []uint8{2, 0}, decoded.Report.ObserverIDs())
+	assert.Equal(t, sigs, decoded.Signatures)
+
+	signers, err := decoded.VerifySignatures(context.Background(), reader, state, rpc.CommitmentConfirmed, logger.TestLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, signers)
+
+	// config replaced since and missing from the state history
+	state.Config.LatestConfigDigest = types.ConfigDigest{2}
+	_, err = decoded.VerifySignatures(context.Background(), reader, state, rpc.CommitmentConfirmed, logger.TestLogger(t))
+	var configErr *ConfigNotInEffectError
+	require.True(t, errors.As(err, &configErr))
+	assert.Error(t, configErr.Err)
+
+	// unknown program or transaction
+	_, err = FetchTransmitTx(context.Background(), reader, solana.NewWallet().PublicKey(), tx.Signatures[0], rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+	_, err = FetchTransmitTx(context.Background(), reader, programID, solana.Signature{1}, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+}
+
+func TestFetchTransmitTx_V0(t *testing.T) {
+	var state State
+	state.Config.F = 1
+	state.Config.LatestConfigDigest = testConfigDigest
+	keys := testSigningKeys(t, &state, 4)
+
+	programID, stateID, transmissionsID, storeProgramID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	transmitter, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	storeAuthority, nonce, err := FindStoreAuthority(programID, stateID)
+	require.NoError(t, err)
+	addresses, err := FeedLookupTableAddresses(programID, stateID, transmissionsID, storeProgramID)
+	require.NoError(t, err)
+	table := LookupTable{Key: solana.NewWallet().PublicKey(), Addresses: addresses}
+
+	reportCtx := types.ReportContext{
+		ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest, Epoch: 7, Round: 3},
+	}
+	report, err := EncodeReport(Report{Timestamp: 1000, ObserversCount: 2, Observers: [32]uint8{1, 3}, Median: big.NewInt(1234), JuelsPerFeeCoin: big.NewInt(5678)})
+	require.NoError(t, err)
+	sigs := []types.AttributedOnchainSignature{testSign(t, keys[1], reportCtx, report), testSign(t, keys[3], reportCtx, report)}
+
+	msg, err := NewMessageV0(
+		[]solana.Instruction{newTransmitInstruction(programID, stateID, transmitter.PublicKey(), transmissionsID, storeProgramID, storeAuthority, nonce, reportCtx, report, sigs)},
+		solana.Hash{1}, transmitter.PublicKey(), []LookupTable{table},
+	)
+	require.NoError(t, err)
+	// state and store authority are loaded from the lookup table
+	require.Len(t, msg.AddressTableLookups, 1)
+	payload, err := msg.MarshalBinary()
+	require.NoError(t, err)
+	txSig, err := transmitter.Sign(payload)
+	require.NoError(t, err)
+	raw, err := VersionedTransaction{Signatures: []solana.Signature{txSig}, Message: msg}.MarshalBinary()
+	require.NoError(t, err)
+	assert.True(t, IsVersionedTransaction(raw))
+
+	data, err := rpc.DataBytesOrJSONFromBase64(base64.StdEncoding.EncodeToString(encodeLookupTable(transmitter.PublicKey(), addresses)))
+	require.NoError(t, err)
+	reader := testTxReader{
+		results: map[solana.Signature]*rpc.GetTransactionResult{txSig: testTxResult(t, 42, raw)},
+		accounts: map[solana.PublicKey]*rpc.GetAccountInfoResult{
+			table.Key: {Value: &rpc.Account{Owner: AddressLookupTableProgramID, Data: data}},
+		},
+	}
+
+	decoded, err := FetchTransmitTx(context.Background(), reader, programID, txSig, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, txSig, decoded.Signature)
+	assert.Equal(t, uint64(42), decoded.Slot)
+	assert.Equal(t, stateID, decoded.StateID)
+	assert.Equal(t, transmitter.PublicKey(), decoded.Transmitter)
+	assert.Equal(t, nonce, decoded.StoreNonce)
+	assert.Equal(t, reportCtx, decoded.ReportContext)
+	assert.Equal(t, report, decoded.RawReport)
+	assert.Equal(t, sigs, decoded.Signatures)
+
+	signers, err := decoded.VerifySignatures(context.Background(), reader, state, rpc.CommitmentConfirmed, logger.TestLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, signers)
+
+	// lookup table is required to resolve the accounts
+	delete(reader.accounts, table.Key)
+	_, err = FetchTransmitTx(context.Background(), reader, programID, txSig, rpc.CommitmentConfirmed)
+	assert.Error(t, err)
+}
+
+func TestTransmitTx_VerifySignatures_History(t *testing.T) {
+	ctx := context.Background()
+	lggr := logger.TestLogger(t)
+	var state State
+	keys := testSigningKeys(t, &state, 4)
+	state.Config.ConfigCount = 3
+	programID, stateID := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	l := newTestLedger(t, programID)
+
+	// config of the transmission, replaced afterwards
+	var signers [][20]byte
+	for _, o := range state.Oracles.Raw[:state.Oracles.Len] {
+		signers = append(signers, o.Signer.Key)
+	}
+	_, cfg := testAcceptedProposal(t, l, stateID, state, 2, testOracles(signers...))
+
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: cfg.ConfigDigest, Epoch: 1, Round: 1}}
+	report, err := EncodeReport(Report{Timestamp: 1000, ObserversCount: 2, Observers: [32]uint8{1, 3}, Median: big.NewInt(1), JuelsPerFeeCoin: big.NewInt(1)})
+	require.NoError(t, err)
+	sigs := []types.AttributedOnchainSignature{testSign(t, keys[3], reportCtx, report), testSign(t, keys[1], reportCtx, report)}
+	storeAuthority, nonce, err := FindStoreAuthority(programID, stateID)
+	require.NoError(t, err)
+	transmitSig := l.send(false, []solana.Instruction{newTransmitInstruction(programID, stateID, l.payer.PublicKey(), solana.NewWallet().PublicKey(),
+		solana.NewWallet().PublicKey(), storeAuthority, nonce, reportCtx, report, sigs)})
+
+	_, latest := testAcceptedProposal(t, l, stateID, state, 3, testOracles([20]byte{1}, [20]byte{2}, [20]byte{3}, [20]byte{4}))
+	state.Config.LatestConfigDigest = latest.ConfigDigest
+
+	tx, err := FetchTransmitTx(ctx, l, programID, transmitSig, rpc.CommitmentConfirmed)
+	require.NoError(t, err)
+	// the oracles of the config in effect are sorted by signer
+	sortedSigners, err := tx.VerifySignatures(ctx, l, state, rpc.CommitmentConfirmed, lggr)
+	require.NoError(t, err)
+	require.Len(t, sortedSigners, 2)
+	for i, key := range []int{3, 1} {
+		assert.Equal(t, cfg.Signers[sortedSigners[i]], types.OnchainPublicKey(signers[key][:]))
+	}
+
+	// report signed for another config
+	tx.ReportContext.ConfigDigest = types.ConfigDigest{5}
+	_, err = tx.VerifySignatures(ctx, l, state, rpc.CommitmentConfirmed, lggr)
+	var configErr *ConfigNotInEffectError
+	require.True(t, errors.As(err, &configErr))
+	assert.NoError(t, configErr.Err)
+	assert.Equal(t, cfg.ConfigDigest, configErr.InEffect)
+
+	// config in effect not recoverable
+	tx.ReportContext.ConfigDigest = cfg.ConfigDigest
+	_, err = tx.VerifySignatures(ctx, newTestLedger(t, programID), state, rpc.CommitmentConfirmed, lggr)
+	require.True(t, errors.As(err, &configErr))
+	assert.Error(t, configErr.Err)
+}
+
+func TestParseTransmitInstruction(t *testing.T) {
+	for _, n := range []int{0, 1 + ReportContextLen + int(ReportLen), 1 + ReportContextLen + int(ReportLen) + SignatureLen - 1} {
+		_, _, _, _, err := ParseTransmitInstruction(make([]byte, n))
+		assert.Error(t, err, "length %d", n)
+	}
+	_, _, report, sigs, err := ParseTransmitInstruction(make([]byte, 1+ReportContextLen+int(ReportLen)+2*SignatureLen))
+	require.NoError(t, err)
+	assert.Len(t, report, int(ReportLen))
+	assert.Len(t, sigs, 2)
+}
@@ -3,6 +3,7 @@ package solana
 import (
 	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/pkg/errors"
@@ -11,6 +12,23 @@ import (
 
 var _ types.ContractTransmitter = (*ContractTracker)(nil)
 
+// healthKeyBalance identifies the transmitter balance condition in the tracker health report
+const healthKeyBalance = "transmitterBalance"
+
+// InsufficientBalanceError is returned by Transmit when the transmitter can not pay the
+// transmission fee without dropping below the configured MinTransmitterBalance
+type InsufficientBalanceError struct {
+	Transmitter solana.PublicKey
+	Balance     uint64 // lamports
+	Fee         uint64 // lamports
+	Min         uint64 // lamports
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("insufficient transmitter balance for %s: balance %d lamports, fee %d lamports, minimum balance %d lamports",
+		e.Transmitter, e.Balance, e.Fee, e.Min)
+}
+
 // Transmit sends the report to the on-chain OCR2Aggregator smart contract's Transmit method
 func (c *ContractTracker) Transmit(
 	ctx context.Context,
@@ -18,74 +36,136 @@ func (c *ContractTracker) Transmit(
 	report types.Report,
 	sigs []types.AttributedOnchainSignature,
 ) error {
+	txSig, err := c.transmit(ctx, reportCtx, report, sigs)
+	if c.auditLog != nil {
+		c.audit(ctx, reportCtx, report, sigs, txSig, err)
+	}
+	return err
+}
+
+// transmit builds, signs and enqueues the transmit transaction, returning the transaction signature once signed
+func (c *ContractTracker) transmit(
+	ctx context.Context,
+	reportCtx types.ReportContext,
+	report types.Report,
+	sigs []types.AttributedOnchainSignature,
+) (txSig solana.Signature, err error) {
 	blockhash, err := c.reader.LatestBlockhash()
 	if err != nil {
-		return errors.Wrap(err, "error on Transmit.GetRecentBlockhash")
+		return txSig, errors.Wrap(err, "error on Transmit.GetRecentBlockhash")
 	}
 	if blockhash == nil || blockhash.Value == nil {
-		return errors.New("nil pointer returned from Transmit.GetRecentBlockhash")
+		return txSig, errors.New("nil pointer returned from Transmit.GetRecentBlockhash")
 	}
 
 	// Determine store authority
-	seeds := [][]byte{[]byte("store"), c.StateID.Bytes()}
-	storeAuthority, storeNonce, err := solana.FindProgramAddress(seeds, c.ProgramID)
+	storeAuthority, storeNonce, err := FindStoreAuthority(c.ProgramID, c.StateID)
 	if err != nil {
-		return errors.Wrap(err, "error on Transmit.FindProgramAddress")
+		return txSig, errors.Wrap(err, "error on Transmit.FindProgramAddress")
 	}
 
-	if _, err = c.ReadState(); err != nil {
-		return errors.Wrap(err, "error on Transmit.ReadState")
-	}
-	accounts := []*solana.AccountMeta{
-		// state, transmitter, transmissions, store_program, store, store_authority
-		{PublicKey: c.StateID, IsWritable: true, IsSigner: false},
-		{PublicKey: c.Transmitter.PublicKey(), IsWritable: false, IsSigner: true},
-		{PublicKey: c.TransmissionsID, IsWritable: true, IsSigner: false},
-		{PublicKey: c.StoreProgramID, IsWritable: false, IsSigner: false},
-		{PublicKey: storeAuthority, IsWritable: false, IsSigner: false},
+	state, err := c.ReadState()
+	if err != nil {
+		return txSig, errors.Wrap(err, "error on Transmit.ReadState")
 	}
 
-	reportContext := RawReportContext(reportCtx)
-
-	// Construct the instruction payload
-	data := new(bytes.Buffer) // store_nonce || report_context || raw_report || raw_signatures
-	data.WriteByte(storeNonce)
-	data.Write(reportContext[0][:])
-	data.Write(reportContext[1][:])
-	data.Write(reportContext[2][:])
-	data.Write([]byte(report))
-	for _, sig := range sigs {
-		// Signature = 64 bytes + 1 byte recovery id
-		data.Write(sig.Signature)
+	// refuse transmissions the program would reject due to job spec and on-chain config drift
+	if err = c.checkTransmitter(state); err != nil {
+		c.lggr.Errorf("Transmit refused: %s", err)
+		return txSig, err
+	}
+	if err = c.checkConfigDigest(state, reportCtx.ConfigDigest); err != nil {
+		var mismatch *ConfigDigestMismatchError
+		if errors.As(err, &mismatch) && mismatch.Replaced {
+			c.lggr.Infof("Transmit refused: %s", err)
+		} else {
+			c.lggr.Errorf("Transmit refused: %s", err)
+		}
+		return txSig, err
 	}
+	if c.cfg.VerifyReportSignatures() {
+		if _, err = VerifyReportSignatures(state, reportCtx, report, sigs); err != nil {
+			c.lggr.Errorf("Transmit refused: %s", err)
+			return txSig, err
+		}
+	}
+
+	ix := newTransmitInstruction(c.ProgramID, c.StateID, c.Transmitter.PublicKey(), c.TransmissionsID, c.StoreProgramID, storeAuthority, storeNonce, reportCtx, report, sigs)
 
 	tx, err := solana.NewTransaction(
-		[]solana.Instruction{
-			solana.NewInstruction(c.ProgramID, accounts, data.Bytes()),
-		},
+		[]solana.Instruction{ix},
 		blockhash.Value.Blockhash,
 		solana.TransactionPayer(c.Transmitter.PublicKey()),
 	)
 	if err != nil {
-		return errors.Wrap(err, "error on Transmit.NewTransaction")
+		return txSig, errors.Wrap(err, "error on Transmit.NewTransaction")
+	}
+
+	// refuse transactions exceeding the packet size limit before signing
+	if err = CheckTxSize(tx.Message); err != nil {
+		c.lggr.Errorf("Transmit refused: %s", err)
+		return txSig, err
+	}
+
+	// refuse to enqueue transactions the transmitter can not pay for
+	if err = c.checkBalance(tx.Message.ToBase64()); err != nil {
+		return txSig, err
 	}
 
 	msgToSign, err := tx.Message.MarshalBinary()
 	if err != nil {
-		return errors.Wrap(err, "error on Transmit.Message.MarshalBinary")
+		return txSig, errors.Wrap(err, "error on Transmit.Message.MarshalBinary")
 	}
 	finalSigBytes, err := c.Transmitter.Sign(msgToSign)
 	if err != nil {
-		return errors.Wrap(err, "error on Transmit.Sign")
+		return txSig, errors.Wrap(err, "error on Transmit.Sign")
 	}
-	var finalSig [64]byte
-	copy(finalSig[:], finalSigBytes)
-	tx.Signatures = append(tx.Signatures, finalSig)
+	copy(txSig[:], finalSigBytes)
+	tx.Signatures = append(tx.Signatures, txSig)
 
 	// pass transmit payload to tx manager queue
 	c.lggr.Debugf("Queuing transmit tx: state (%s) + transmissions (%s)", c.StateID.String(), c.TransmissionsID.String())
 	err = c.txManager.Enqueue(c.StateID.String(), tx)
-	return errors.Wrap(err, "error on Transmit.txManager.Enqueue")
+	return txSig, errors.Wrap(err, "error on Transmit.txManager.Enqueue")
+}
+
+// FindStoreAuthority derives the store authority PDA of the OCR2 program for the state account
+func FindStoreAuthority(programID, stateID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	seeds := [][]byte{[]byte("store"), stateID.Bytes()}
+	return solana.FindProgramAddress(seeds, programID)
+}
+
+// newTransmitInstruction builds the OCR2 program transmit instruction
+func newTransmitInstruction(
+	programID, stateID, transmitter, transmissionsID, storeProgramID, storeAuthority solana.PublicKey,
+	storeNonce uint8,
+	reportCtx types.ReportContext,
+	report types.Report,
+	sigs []types.AttributedOnchainSignature,
+) solana.Instruction {
+	accounts := []*solana.AccountMeta{
+		// state, transmitter, transmissions, store_program, store, store_authority
+		{PublicKey: stateID, IsWritable: true, IsSigner: false},
+		{PublicKey: transmitter, IsWritable: false, IsSigner: true},
+		{PublicKey: transmissionsID, IsWritable: true, IsSigner: false},
+		{PublicKey: storeProgramID, IsWritable: false, IsSigner: false},
+		{PublicKey: storeAuthority, IsWritable: false, IsSigner: false},
+	}
+
+	reportContext := RawReportContext(reportCtx)
+
+	// Construct the instruction payload
+	data := new(bytes.Buffer) // store_nonce || report_context || raw_report || raw_signatures
+	data.WriteByte(storeNonce)
+	data.Write(reportContext[0][:])
+	data.Write(reportContext[1][:])
+	data.Write(reportContext[2][:])
+	data.Write([]byte(report))
+	for _, sig := range sigs {
+		// Signature = 64 bytes + 1 byte recovery id
+		data.Write(sig.Signature)
+	}
+	return solana.NewInstruction(programID, accounts, data.Bytes())
 }
 
 func (c *ContractTracker) LatestConfigDigestAndEpoch(
@@ -102,3 +182,31 @@ func (c *ContractTracker) LatestConfigDigestAndEpoch(
 func (c *ContractTracker) FromAccount() types.Account {
 	return types.Account(c.Transmitter.PublicKey().String())
 }
+
+// checkBalance estimates the fee for the base64 encoded message and compares it against the cached transmitter balance.
+// A shortfall is reported as an InsufficientBalanceError and marks the tracker unhealthy until resolved.
+func (c *ContractTracker) checkBalance(msg string) error {
+	fee, err := c.reader.GetFeeForMessage(msg)
+	if err != nil {
+		return errors.Wrap(err, "error on Transmit.GetFeeForMessage")
+	}
+	balance, err := c.ReadBalance()
+	if err != nil {
+		return errors.Wrap(err, "error on Transmit.ReadBalance")
+	}
+
+	min := c.cfg.MinTransmitterBalance()
+	if balance < fee || balance-fee < min {
+		balanceErr := &InsufficientBalanceError{
+			Transmitter: c.Transmitter.PublicKey(),
+			Balance:     balance,
+			Fee:         fee,
+			Min:         min,
+		}
+		c.health.Set(healthKeyBalance, balanceErr)
+		c.lggr.Errorf("Transmit refused: %s", balanceErr)
+		return balanceErr
+	}
+	c.health.Set(healthKeyBalance, nil)
+	return nil
+}
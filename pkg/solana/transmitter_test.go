@@ -0,0 +1,256 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/smartcontractkit/chainlink/core/logger"
+	"github.com/smartcontractkit/libocr/commontypes"
+	"github.com/smartcontractkit/libocr/offchainreporting2/reportingplugin/median"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/audit"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/config"
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/db"
+)
+
+type testSigner struct {
+	key solana.PrivateKey
+}
+
+func newTestSigner(t *testing.T) testSigner {
+	key, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	return testSigner{key}
+}
+
+func (s testSigner) Sign(msg []byte) ([]byte, error) {
+	sig, err := s.key.Sign(msg)
+	return sig[:], err
+}
+
+func (s testSigner) PublicKey() solana.PublicKey {
+	return s.key.PublicKey()
+}
+
+type testTxManager struct {
+	lock sync.Mutex
+	txs  []*solana.Transaction
+}
+
+func (m *testTxManager) Enqueue(accountID string, tx *solana.Transaction) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.txs = append(m.txs, tx)
+	return nil
+}
+
+// testObservations returns n observations with value, timestamp and juels equal to the observer index
+func testObservations(n int) []median.ParsedAttributedObservation {
+	oo := []median.ParsedAttributedObservation{}
+	for i := 0; i < n; i++ {
+		oo = append(oo, median.ParsedAttributedObservation{
+			Timestamp:       uint32(i),
+			Value:           big.NewInt(int64(i)),
+			JuelsPerFeeCoin: big.NewInt(int64(i)),
+			Observer:        commontypes.OracleID(i),
+		})
+	}
+	return oo
+}
+
+var testConfigDigest = types.ConfigDigest{1}
+
+func testSetupTransmitter(t *testing.T, reader *mocks.ReaderWriter, minBalance int64) (*ContractTracker, *testTxManager) {
+	lggr := logger.TestLogger(t)
+	txm := &testTxManager{}
+	cfg := config.NewConfig(db.ChainCfg{MinTransmitterBalance: null.IntFrom(minBalance)}, lggr)
+	tracker := NewTracker(OCR2Spec{
+		ProgramID:       solana.NewWallet().PublicKey(),
+		StateID:         solana.NewWallet().PublicKey(),
+		StoreProgramID:  solana.NewWallet().PublicKey(),
+		TransmissionsID: solana.NewWallet().PublicKey(),
+	}, cfg, reader, txm, newTestSigner(t), lggr)
+	// fresh cached state with the transmitter in the oracle set
+	tracker.state.Config.LatestConfigDigest = testConfigDigest
+	tracker.state.Oracles.Raw[0].Transmitter = tracker.Transmitter.PublicKey()
+	tracker.state.Oracles.Len = 1
+	tracker.stateTime = time.Now()
+
+	reader.On("LatestBlockhash").Return(&rpc.GetLatestBlockhashResult{
+		Value: &rpc.LatestBlockhashResult{Blockhash: solana.Hash{1}},
+	}, nil)
+	reader.On("GetFeeForMessage", mock.Anything).Return(uint64(5000), nil)
+	return &tracker, txm
+}
+
+func TestTransmit_BalanceCheck(t *testing.T) {
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest}}
+	report := make(types.Report, ReportLen)
+
+	t.Run("sufficient balance", func(t *testing.T) {
+		reader := new(mocks.ReaderWriter)
+		tracker, txm := testSetupTransmitter(t, reader, 1000)
+		reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(6000), nil).Once()
+
+		require.NoError(t, tracker.Transmit(context.Background(), reportCtx, report, nil))
+		// second transmit uses the cached balance
+		require.NoError(t, tracker.Transmit(context.Background(), reportCtx, report, nil))
+		assert.Len(t, txm.txs, 2)
+		assert.NoError(t, tracker.health.Err())
+		reader.AssertExpectations(t)
+	})
+
+	t.Run("below minimum balance", func(t *testing.T) {
+		reader := new(mocks.ReaderWriter)
+		tracker, txm := testSetupTransmitter(t, reader, 1001)
+		reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(6000), nil).Once()
+
+		err := tracker.Transmit(context.Background(), reportCtx, report, nil)
+		var balanceErr *InsufficientBalanceError
+		require.True(t, errors.As(err, &balanceErr))
+		assert.Equal(t, uint64(6000), balanceErr.Balance)
+		assert.Equal(t, uint64(5000), balanceErr.Fee)
+		assert.Equal(t, uint64(1001), balanceErr.Min)
+		assert.Empty(t, txm.txs)
+		assert.Error(t, tracker.health.Err())
+
+		// health recovers once the transmitter is funded
+		tracker.balance = 10000
+		require.NoError(t, tracker.Transmit(context.Background(), reportCtx, report, nil))
+		assert.Len(t, txm.txs, 1)
+		assert.NoError(t, tracker.health.Err())
+	})
+
+	t.Run("balance below fee", func(t *testing.T) {
+		reader := new(mocks.ReaderWriter)
+		tracker, txm := testSetupTransmitter(t, reader, 0)
+		reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(4999), nil).Once()
+
+		err := tracker.Transmit(context.Background(), reportCtx, report, nil)
+		var balanceErr *InsufficientBalanceError
+		assert.True(t, errors.As(err, &balanceErr))
+		assert.Empty(t, txm.txs)
+	})
+}
+
+func TestTransmit_Audit(t *testing.T) {
+	ctx := context.Background()
+	reader := new(mocks.ReaderWriter)
+	tracker, _ := testSetupTransmitter(t, reader, 0)
+	reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(6000), nil).Once()
+
+	sink, err := audit.NewFileSink(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	tracker.auditLog, err = audit.NewLog(ctx, sink)
+	require.NoError(t, err)
+
+	report, err := ReportCodec{}.BuildReport(testObservations(3))
+	require.NoError(t, err)
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest, Epoch: 2, Round: 3}}
+	sigs := []types.AttributedOnchainSignature{{Signature: make([]byte, 65)}, {Signature: make([]byte, 65), Signer: 1}}
+	require.NoError(t, tracker.Transmit(ctx, reportCtx, report, sigs))
+
+	// stale state fails the transmission
+	tracker.stateTime = time.Time{}
+	require.Error(t, tracker.Transmit(ctx, reportCtx, report, sigs))
+
+	records, err := tracker.auditLog.Query(ctx, audit.Filter{StateID: tracker.StateID})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, audit.StatusQueued, records[0].Status)
+	assert.False(t, records[0].TxSignature.IsZero())
+	assert.Equal(t, testConfigDigest, records[0].ConfigDigest)
+	assert.Equal(t, uint32(2), records[0].Epoch)
+	assert.Equal(t, uint8(3), records[0].Round)
+	assert.Equal(t, 2, records[0].Signatures)
+	assert.Equal(t, big.NewInt(1), records[0].Median)
+	assert.Equal(t, []int{0, 1, 2}, records[0].Observers)
+	assert.Equal(t, audit.StatusFailed, records[1].Status)
+	assert.Contains(t, records[1].Error, "Transmit.ReadState")
+	assert.True(t, records[1].TxSignature.IsZero())
+}
+
+func TestTransmit_OracleSetCheck(t *testing.T) {
+	ctx := context.Background()
+	reader := new(mocks.ReaderWriter)
+	tracker, txm := testSetupTransmitter(t, reader, 0)
+	reader.On("Balance", tracker.Transmitter.PublicKey()).Return(uint64(6000), nil).Once()
+	report := make(types.Report, ReportLen)
+
+	// report generated for a config replaced since
+	replacedDigest := types.ConfigDigest{3}
+	tracker.configs.Add(1, types.ContractConfig{ConfigDigest: replacedDigest})
+	err := tracker.Transmit(ctx, types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: replacedDigest}}, report, nil)
+	var digestErr *ConfigDigestMismatchError
+	require.True(t, errors.As(err, &digestErr))
+	assert.True(t, digestErr.Replaced)
+	assert.NoError(t, tracker.health.Err())
+
+	// report generated for an unknown config
+	staleDigest := types.ConfigDigest{2}
+	err = tracker.Transmit(ctx, types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: staleDigest}}, report, nil)
+	require.True(t, errors.As(err, &digestErr))
+	assert.Equal(t, staleDigest, digestErr.Report)
+	assert.Equal(t, testConfigDigest, digestErr.OnChain)
+	assert.False(t, digestErr.Replaced)
+	assert.Error(t, tracker.health.Err())
+
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest}}
+	require.NoError(t, tracker.Transmit(ctx, reportCtx, report, nil))
+	assert.NoError(t, tracker.health.Err())
+
+	// cleared once the poller sees a new config
+	err = tracker.Transmit(ctx, types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: staleDigest}}, report, nil)
+	require.Error(t, err)
+	assert.Error(t, tracker.health.Err())
+	tracker.onState(tracker.state, true)
+	assert.NotContains(t, tracker.health.issues, healthKeyConfigDigest)
+
+	// transmitter rotated out of the oracle set
+	tracker.state.Oracles.Raw[0].Transmitter = solana.NewWallet().PublicKey()
+	err = tracker.Transmit(ctx, reportCtx, report, nil)
+	var notFoundErr *TransmitterNotInOracleSetError
+	require.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, tracker.Transmitter.PublicKey(), notFoundErr.Transmitter)
+	assert.Error(t, tracker.health.Err())
+	assert.Len(t, txm.txs, 1)
+}
+
+func TestContractTracker_OracleSetDriftLogged(t *testing.T) {
+	reader := new(mocks.ReaderWriter)
+	tracker, _ := testSetupTransmitter(t, reader, 0)
+	lggr, logs := logger.TestLoggerObserved(t, zapcore.InfoLevel)
+	tracker.lggr = lggr
+	state := tracker.state
+
+	// polled every second, logged when the drift starts
+	state.Oracles.Raw[0].Transmitter = solana.NewWallet().PublicKey()
+	for i := 0; i < 3; i++ {
+		tracker.onState(state, false)
+	}
+	assert.Error(t, tracker.health.Err())
+	assert.Equal(t, 1, logs.FilterMessageSnippet("drift").Len())
+
+	// and when it ends
+	state.Oracles.Raw[0].Transmitter = tracker.Transmitter.PublicKey()
+	for i := 0; i < 3; i++ {
+		tracker.onState(state, false)
+	}
+	assert.NoError(t, tracker.health.Err())
+	assert.Equal(t, 1, logs.FilterMessageSnippet("again").Len())
+	assert.Equal(t, 2, logs.Len())
+}
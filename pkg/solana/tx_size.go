@@ -0,0 +1,92 @@
+package solana
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/pkg/errors"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+)
+
+const (
+	// MaxTxSize is the maximum serialized transaction size (solana PACKET_DATA_SIZE = 1280 - 40 - 8)
+	MaxTxSize = 1232
+	// SignatureLen is the length of an oracle report signature (64 bytes + 1 byte recovery id)
+	SignatureLen = 65
+)
+
+// TxTooLargeError is returned when a serialized transaction exceeds MaxTxSize
+type TxTooLargeError struct {
+	Size int
+	Max  int
+}
+
+func (e *TxTooLargeError) Error() string {
+	return fmt.Sprintf("serialized transaction size %d bytes exceeds maximum of %d bytes", e.Size, e.Max)
+}
+
+// TxSize returns the serialized size of a transaction for msg once all required signatures are attached
+func TxSize(msg solana.Message) (int, error) {
+	raw, err := msg.MarshalBinary()
+	if err != nil {
+		return 0, errors.Wrap(err, "error in TxSize.MarshalBinary")
+	}
+	n := int(msg.Header.NumRequiredSignatures)
+	return compactU16Len(n) + n*solana.SignatureLength + len(raw), nil
+}
+
+// CheckTxSize returns a TxTooLargeError if the transaction for msg would exceed MaxTxSize
+func CheckTxSize(msg solana.Message) error {
+	size, err := TxSize(msg)
+	if err != nil {
+		return err
+	}
+	if size > MaxTxSize {
+		return &TxTooLargeError{Size: size, Max: MaxTxSize}
+	}
+	return nil
+}
+
+// WorstCaseTransmitTxSize returns the serialized size of a transmit transaction carrying f+1 signatures,
+// the maximum number of signatures the program accepts for a config with the given F and oracle count.
+func WorstCaseTransmitTxSize(f, maxOracles int) (int, error) {
+	if maxOracles <= 0 || maxOracles > MaxOracles {
+		return 0, fmt.Errorf("oracle count %d out of range (1..%d)", maxOracles, MaxOracles)
+	}
+	if f <= 0 || 3*f >= maxOracles {
+		return 0, fmt.Errorf("f %d out of range for %d oracles (requires 0 < 3f < n)", f, maxOracles)
+	}
+
+	// accounts are distinct placeholders, sizes do not depend on their values
+	keys := make([]solana.PublicKey, 6)
+	for i := range keys {
+		keys[i][0] = byte(i + 1)
+	}
+	sigs := make([]types.AttributedOnchainSignature, f+1)
+	for i := range sigs {
+		sigs[i].Signature = make([]byte, SignatureLen)
+	}
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			newTransmitInstruction(keys[0], keys[1], keys[2], keys[3], keys[4], keys[5], 0, types.ReportContext{}, make(types.Report, ReportLen), sigs),
+		},
+		solana.Hash{},
+		solana.TransactionPayer(keys[2]),
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "error in WorstCaseTransmitTxSize.NewTransaction")
+	}
+	return TxSize(tx.Message)
+}
+
+// compactU16Len returns the length of the compact-u16 (shortvec) encoding of n
+func compactU16Len(n int) int {
+	switch {
+	case n < 0x80:
+		return 1
+	case n < 0x4000:
+		return 2
+	default:
+		return 3
+	}
+}
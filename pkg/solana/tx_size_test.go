@@ -0,0 +1,74 @@
+package solana
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/smartcontractkit/libocr/offchainreporting2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-solana/pkg/solana/client/mocks"
+)
+
+func TestTxSize(t *testing.T) {
+	key, err := solana.NewRandomPrivateKey()
+	require.NoError(t, err)
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{system.NewTransferInstruction(1, key.PublicKey(), solana.NewWallet().PublicKey()).Build()},
+		solana.Hash{1},
+		solana.TransactionPayer(key.PublicKey()),
+	)
+	require.NoError(t, err)
+
+	size, err := TxSize(tx.Message)
+	require.NoError(t, err)
+
+	// matches the signed transaction
+	_, err = tx.Sign(func(solana.PublicKey) *solana.PrivateKey { return &key })
+	require.NoError(t, err)
+	raw, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, len(raw), size)
+	assert.NoError(t, CheckTxSize(tx.Message))
+}
+
+func TestWorstCaseTransmitTxSize(t *testing.T) {
+	prev := 0
+	for f := 1; 3*f < MaxOracles; f++ {
+		size, err := WorstCaseTransmitTxSize(f, MaxOracles)
+		require.NoError(t, err)
+		if prev > 0 {
+			assert.Equal(t, SignatureLen, size-prev) // each additional signature adds 65 bytes
+		}
+		assert.LessOrEqual(t, size, MaxTxSize)
+		prev = size
+	}
+
+	for _, tc := range []struct{ f, n int }{
+		{0, 4}, {1, 3}, {2, 6}, {1, MaxOracles + 1}, {1, 0},
+	} {
+		_, err := WorstCaseTransmitTxSize(tc.f, tc.n)
+		assert.Error(t, err, "f=%d n=%d", tc.f, tc.n)
+	}
+}
+
+func TestTransmit_TxTooLarge(t *testing.T) {
+	reader := new(mocks.ReaderWriter)
+	tracker, txm := testSetupTransmitter(t, reader, 0)
+
+	sigs := make([]types.AttributedOnchainSignature, 20)
+	for i := range sigs {
+		sigs[i].Signature = make([]byte, SignatureLen)
+	}
+	reportCtx := types.ReportContext{ReportTimestamp: types.ReportTimestamp{ConfigDigest: testConfigDigest}}
+	err := tracker.Transmit(context.Background(), reportCtx, make(types.Report, ReportLen), sigs)
+	var sizeErr *TxTooLargeError
+	require.True(t, errors.As(err, &sizeErr))
+	assert.Equal(t, MaxTxSize, sizeErr.Max)
+	assert.Greater(t, sizeErr.Size, MaxTxSize)
+	assert.Empty(t, txm.txs)
+}
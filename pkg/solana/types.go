@@ -2,7 +2,9 @@ package solana
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
+	"strings"
 
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
@@ -18,6 +20,12 @@ const (
 	TransmissionsHeaderLen     uint64 = 1 + 1 + 32 + 32 + 32 + 32 + 1 + 4 + 4 + 1 + 4 + 4 + 4
 	TransmissionsHeaderMaxSize uint64 = 192 // max area allocated to transmissions header
 
+	// StateHeaderLen = AccountDiscriminator, Version, Nonce, Padding0, Padding1, Transmissions
+	StateHeaderLen uint64 = 8 + 1 + 1 + 2 + 4 + 32
+	// ConfigLen = Owner, ProposedOwner, TokenMint, TokenVault, RequesterAccessController, BillingAccessController, MinAnswer, MaxAnswer,
+	// F, Round, Padding0, Epoch, LatestAggregatorRoundID, LatestTransmitter, ConfigCount, LatestConfigDigest, LatestConfigBlockNumber, Billing
+	ConfigLen uint64 = 32*6 + 16 + 16 + 1 + 1 + 2 + 4 + 4 + 32 + 4 + 32 + 8 + 4 + 4
+
 	// ReportLen data (61 bytes)
 	MedianLen       uint64 = 16
 	JuelsLen        uint64 = 8
@@ -28,6 +36,9 @@ const (
 	MaxOracles = 19
 	// MaxOffchainConfigLen is the maximum byte length for the encoded offchainconfig
 	MaxOffchainConfigLen = 4096
+
+	// FeedVersion is the current transmissions account version
+	FeedVersion uint8 = 2
 )
 
 // State is the struct representing the contract state
@@ -143,6 +154,62 @@ type TransmissionsHeader struct {
 	HistoricalCursor  uint32
 }
 
+// FeedState is the flag state of a feed
+type FeedState uint8
+
+const (
+	FeedStateNormal  FeedState = 0
+	FeedStateFlagged FeedState = 1
+)
+
+func (s FeedState) String() string {
+	switch s {
+	case FeedStateNormal:
+		return "NORMAL"
+	case FeedStateFlagged:
+		return "FLAGGED"
+	default:
+		return fmt.Sprintf("UNKNOWN(%d)", uint8(s))
+	}
+}
+
+// FeedHeader is the typed header of a store program transmissions account
+type FeedHeader struct {
+	Version           uint8
+	State             FeedState
+	Owner             solana.PublicKey
+	ProposedOwner     solana.PublicKey
+	Writer            solana.PublicKey
+	Description       string // trailing nulls stripped
+	Decimals          uint8
+	FlaggingThreshold uint32
+	LatestRoundID     uint32
+	Granularity       uint8
+	LiveLength        uint32
+	LiveCursor        uint32
+	HistoricalCursor  uint32
+}
+
+// NewFeedHeader converts a decoded transmissions header, invalid UTF-8 in the description is replaced
+func NewFeedHeader(h TransmissionsHeader) FeedHeader {
+	description := strings.TrimRight(string(h.Description[:]), "\x00")
+	return FeedHeader{
+		Version:           h.Version,
+		State:             FeedState(h.State),
+		Owner:             h.Owner,
+		ProposedOwner:     h.ProposedOwner,
+		Writer:            h.Writer,
+		Description:       strings.ToValidUTF8(description, "\uFFFD"),
+		Decimals:          h.Decimals,
+		FlaggingThreshold: h.FlaggingThreshold,
+		LatestRoundID:     h.LatestRoundID,
+		Granularity:       h.Granularity,
+		LiveLength:        h.LiveLength,
+		LiveCursor:        h.LiveCursor,
+		HistoricalCursor:  h.HistoricalCursor,
+	}
+}
+
 // Transmission struct for decoding individual tranmissions
 type Transmission struct {
 	Slot      uint64
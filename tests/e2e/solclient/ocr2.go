@@ -2,13 +2,12 @@ package solclient
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/binary"
 
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/rs/zerolog/log"
 	ocr_2 "github.com/smartcontractkit/chainlink-solana/contracts/generated/ocr2"
+	relaySol "github.com/smartcontractkit/chainlink-solana/pkg/solana"
 	"github.com/smartcontractkit/chainlink-solana/tests/e2e/utils"
 	"github.com/smartcontractkit/integrations-framework/contracts"
 	"github.com/smartcontractkit/libocr/offchainreporting2/confighelper"
@@ -125,22 +124,7 @@ func (m *OCRv2) makeDigest() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	hasher := sha256.New()
-	hasher.Write(append([]byte{}, uint8(proposal.Oracles.Len)))
-	for _, oracle := range proposal.Oracles.Xs[:proposal.Oracles.Len] {
-		hasher.Write(oracle.Signer.Key[:])
-		hasher.Write(oracle.Transmitter.Bytes())
-		hasher.Write(oracle.Payee.Bytes())
-	}
-
-	hasher.Write(append([]byte{}, proposal.F))
-	hasher.Write(proposal.TokenMint.Bytes())
-	header := make([]byte, 8+4)
-	binary.BigEndian.PutUint64(header, proposal.OffchainConfig.Version)
-	binary.BigEndian.PutUint32(header[8:], uint32(proposal.OffchainConfig.Len))
-	hasher.Write(header)
-	hasher.Write(proposal.OffchainConfig.Xs[:proposal.OffchainConfig.Len])
-	return hasher.Sum(nil), nil
+	return relaySol.ProposalDigest(*proposal)
 }
 
 func (m *OCRv2) fetchProposalAccount() (*ocr_2.Proposal, error) {
@@ -214,7 +198,14 @@ func (m *OCRv2) Configure(cfg contracts.OffChainAggregatorV2Config) error {
 	if err != nil {
 		return err
 	}
-	chunks := utils.ChunkSlice(cfgBytes, 1000)
+	if err = relaySol.ValidateOffchainConfigLen(cfgBytes); err != nil {
+		return err
+	}
+	chunkLen, err := relaySol.OffchainConfigChunkLen()
+	if err != nil {
+		return err
+	}
+	chunks := utils.ChunkSlice(cfgBytes, chunkLen)
 	if err = m.createProposal(version); err != nil {
 		return err
 	}
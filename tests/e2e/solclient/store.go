@@ -18,7 +18,7 @@ type Store struct {
 }
 
 func (m *Store) GetLatestRoundData() (uint64, uint64, uint64, error) {
-	a, _, err := relaySol.GetLatestTransmission(context.Background(), m.Client.RPC, m.Feed.PublicKey(), rpc.CommitmentConfirmed)
+	a, _, err := relaySol.GetLatestTransmission(context.Background(), m.Client.RPC, m.ProgramWallet.PublicKey(), m.Feed.PublicKey(), rpc.CommitmentConfirmed)
 	if err != nil {
 		return 0, 0, 0, err
 	}